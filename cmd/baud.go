@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/blue-cloud-net/tjc-serial-display/internal/client"
+)
+
+func handleBaud(args []string) {
+	if len(args) < 1 {
+		fmt.Fprintf(os.Stderr, "Error: baud command requires a baud rate\n")
+		fmt.Fprintf(os.Stderr, "Usage: tjs-serial-display baud <rate> [--save] [-p|--port <port>] [-b|--baud <rate>] [-a|--auto]\n")
+		os.Exit(1)
+	}
+
+	rate, err := strconv.Atoi(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid baud rate %q\n", args[0])
+		os.Exit(1)
+	}
+
+	fs := flag.NewFlagSet("baud", flag.ExitOnError)
+	port := fs.String("port", "", "Serial port path")
+	portShort := fs.String("p", "", "Serial port path (short)")
+	baud := fs.Int("baud", defaultBaudRate, "Current baud rate")
+	baudShort := fs.Int("b", defaultBaudRate, "Current baud rate (short)")
+	auto := fs.Bool("auto", false, "Auto detect serial port")
+	autoShort := fs.Bool("a", false, "Auto detect serial port (short)")
+	save := fs.Bool("save", false, "Also change the power-on default baud rate (bauds)")
+
+	fs.Parse(args[1:])
+
+	portName := getStringFlag(*port, *portShort)
+	baudRate := getIntFlag(*baud, *baudShort, defaultBaudRate)
+	autoDetect := *auto || *autoShort
+
+	if portName == "" && !autoDetect {
+		autoDetect = true
+	}
+
+	if portName != "" && autoDetect {
+		fmt.Fprintf(os.Stderr, "Error: --port and --auto cannot be used together\n")
+		os.Exit(1)
+	}
+
+	var c *client.TjcDisplayClient
+
+	if autoDetect {
+		c, err = autoDetectDevice()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	} else {
+		c = &client.TjcDisplayClient{
+			PortName: portName,
+			BaudRate: baudRate,
+		}
+	}
+	defer c.Close()
+
+	// 打开串口后才能确定当前波特率（设备身份会解析出实际的波特率）
+	err = c.Open()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening port: %v\n", err)
+		os.Exit(1)
+	}
+	previous := c.BaudRate
+
+	err = c.SetDeviceBaudRate(rate, *save)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error changing baud rate: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *save {
+		fmt.Printf("Baud rate changed from %d to %d (saved as power-on default)\n", previous, rate)
+	} else {
+		fmt.Printf("Baud rate changed from %d to %d (until the device restarts)\n", previous, rate)
+	}
+}
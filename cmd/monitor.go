@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/internal/client"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/consts"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/models"
+)
+
+const monitorTimeFormat = "2006-01-02 15:04:05.000"
+
+// 不属于事件类型的其他返回码名称
+var monitorCodeNames = map[byte]string{
+	consts.CodeTransparentReady: "transparent_ready",
+	consts.CodeTransparentDone:  "transparent_done",
+}
+
+func handleMonitor(args []string) {
+	fs := flag.NewFlagSet("monitor", flag.ExitOnError)
+	port := fs.String("port", "", "Serial port path")
+	portShort := fs.String("p", "", "Serial port path (short)")
+	baud := fs.Int("baud", defaultBaudRate, "Baud rate")
+	baudShort := fs.Int("b", defaultBaudRate, "Baud rate (short)")
+	auto := fs.Bool("auto", false, "Auto detect serial port")
+	autoShort := fs.Bool("a", false, "Auto detect serial port (short)")
+	hexDump := fs.Bool("hex", false, "Show hex dump of each frame")
+	jsonOutput := fs.Bool("json", false, "Output one JSON object per line")
+
+	fs.Parse(args)
+
+	portName := getStringFlag(*port, *portShort)
+	baudRate := getIntFlag(*baud, *baudShort, defaultBaudRate)
+	autoDetect := *auto || *autoShort
+
+	if portName == "" && !autoDetect {
+		autoDetect = true
+	}
+
+	if portName != "" && autoDetect {
+		fmt.Fprintf(os.Stderr, "Error: --port and --auto cannot be used together\n")
+		os.Exit(1)
+	}
+
+	var c *client.TjcDisplayClient
+
+	if autoDetect {
+		var err error
+		c, err = autoDetectDevice()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	} else {
+		c = &client.TjcDisplayClient{
+			PortName: portName,
+			BaudRate: baudRate,
+		}
+	}
+	defer c.Close()
+
+	// 只监听，打开串口时不发送初始化指令，避免改变设备的 bkcmd
+	c.Passive = true
+
+	err := c.Open()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening port: %v\n", err)
+		os.Exit(1)
+	}
+
+	// JSON 输出时提示信息写到标准错误，保证标准输出每行都是 JSON
+	fmt.Fprintf(os.Stderr, "Monitoring %s (baud: %d), press Ctrl-C to stop...\n", c.PortName, c.BaudRate)
+
+	unsubscribe := c.SubscribeFrames(func(frame []byte) {
+		record := decodeTraffic(frame, time.Now())
+		if *jsonOutput {
+			writeTrafficJSON(os.Stdout, record)
+		} else {
+			writeTrafficText(os.Stdout, record, *hexDump)
+		}
+	})
+	defer unsubscribe()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	<-signals
+}
+
+// trafficRecord 解码后的一帧串口数据
+type trafficRecord struct {
+	Time   time.Time
+	Frame  []byte
+	Kind   string         // event、success、error、data、raw
+	Text   string         // 可读描述
+	Fields map[string]any // 解码出的字段
+}
+
+// decodeTraffic 根据返回码解码一帧数据
+func decodeTraffic(frame []byte, at time.Time) *trafficRecord {
+	record := &trafficRecord{
+		Time:   at,
+		Frame:  frame,
+		Kind:   "raw",
+		Fields: map[string]any{},
+	}
+
+	// 没有结束符的数据（如 print 输出）原样显示
+	if !bytes.HasSuffix(frame, client.EndSymbol) {
+		record.Text = fmt.Sprintf("raw %q", frame)
+		record.Fields["text"] = string(frame)
+		return record
+	}
+
+	resp, err := client.ParseResponse(frame)
+	if err != nil {
+		record.Text = fmt.Sprintf("raw % X", frame)
+		return record
+	}
+
+	code := fmt.Sprintf("0x%02X", resp.Code)
+	record.Fields["code"] = code
+
+	switch resp.Type {
+	case client.ResponseTypeSuccess:
+		record.Kind = "success"
+		record.Text = code + " success"
+	case client.ResponseTypeError:
+		record.Kind = "error"
+		if tjcErr, ok := resp.Err().(*client.TjcError); ok {
+			record.Fields["message"] = tjcErr.Message
+			record.Text = code + " error: " + tjcErr.Message
+		}
+	case client.ResponseTypeEvent:
+		record.Kind = "event"
+		event, ok := resp.Event()
+		if !ok {
+			name := monitorCodeNames[resp.Code]
+			if name == "" {
+				name = "unknown"
+			}
+			record.Fields["event"] = name
+			record.Text = code + " " + name
+			break
+		}
+
+		record.Fields["event"] = event.Type.String()
+		record.Text = code + " " + event.String()
+		switch event.Type {
+		case models.EventTouch:
+			record.Fields["page"] = event.Page
+			record.Fields["component"] = event.Component
+			record.Fields["pressed"] = event.Pressed
+		case models.EventPage:
+			record.Fields["page"] = event.Page
+		case models.EventTouchCoordinate, models.EventSleepTouch:
+			record.Fields["x"] = event.X
+			record.Fields["y"] = event.Y
+			record.Fields["pressed"] = event.Pressed
+		}
+	case client.ResponseTypeData:
+		value, err := resp.Value()
+		if err != nil {
+			// 未知返回码
+			record.Text = fmt.Sprintf("raw % X", frame)
+			break
+		}
+
+		record.Kind = "data"
+		record.Fields["value"] = value
+		record.Text = fmt.Sprintf("%s data %#v", code, value)
+	}
+
+	return record
+}
+
+func writeTrafficText(w io.Writer, record *trafficRecord, hexDump bool) {
+	line := record.Time.Format(monitorTimeFormat) + "  " + record.Text
+	if hexDump {
+		line += fmt.Sprintf("  [% X]", record.Frame)
+	}
+
+	fmt.Fprintln(w, line)
+}
+
+func writeTrafficJSON(w io.Writer, record *trafficRecord) {
+	out := make(map[string]any, len(record.Fields)+3)
+	for key, value := range record.Fields {
+		out[key] = value
+	}
+	out["time"] = record.Time.Format(time.RFC3339Nano)
+	out["kind"] = record.Kind
+	out["hex"] = strings.ToUpper(hex.EncodeToString(record.Frame))
+
+	data, err := json.Marshal(out)
+	if err != nil {
+		return
+	}
+
+	fmt.Fprintln(w, string(data))
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/internal/client"
+	"github.com/blue-cloud-net/tjc-serial-display/internal/mqttbridge"
+)
+
+const defaultMQTTPrefix = "tjc"
+
+func handleMQTT(args []string) {
+	fs := flag.NewFlagSet("mqtt", flag.ExitOnError)
+	port := fs.String("port", "", "Serial port path")
+	portShort := fs.String("p", "", "Serial port path (short)")
+	baud := fs.Int("baud", defaultBaudRate, "Baud rate")
+	baudShort := fs.Int("b", defaultBaudRate, "Baud rate (short)")
+	auto := fs.Bool("auto", false, "Auto detect serial port")
+	autoShort := fs.Bool("a", false, "Auto detect serial port (short)")
+	broker := fs.String("broker", "", "MQTT broker address, e.g. tcp://localhost:1883")
+	prefix := fs.String("prefix", defaultMQTTPrefix, "Topic prefix")
+	clientID := fs.String("client-id", "", "MQTT client ID (default: random)")
+	username := fs.String("username", "", "MQTT username")
+	password := fs.String("password", "", "MQTT password")
+	maxReconnect := fs.Duration("max-reconnect", 30*time.Second, "Maximum interval between reconnect attempts")
+
+	fs.Parse(args)
+
+	portName := getStringFlag(*port, *portShort)
+	baudRate := getIntFlag(*baud, *baudShort, defaultBaudRate)
+	autoDetect := *auto || *autoShort
+
+	if portName == "" && !autoDetect {
+		autoDetect = true
+	}
+
+	if portName != "" && autoDetect {
+		fmt.Fprintf(os.Stderr, "Error: --port and --auto cannot be used together\n")
+		os.Exit(1)
+	}
+
+	if *broker == "" {
+		fmt.Fprintf(os.Stderr, "Error: --broker is required\n")
+		os.Exit(1)
+	}
+
+	var c *client.TjcDisplayClient
+
+	if autoDetect {
+		var err error
+		c, err = autoDetectDevice()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	} else {
+		c = &client.TjcDisplayClient{
+			PortName: portName,
+			BaudRate: baudRate,
+		}
+	}
+	c.AutoReconnect = true
+	c.MaxReconnectInterval = *maxReconnect
+	defer c.Close()
+
+	err := c.Open()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening port: %v\n", err)
+		os.Exit(1)
+	}
+
+	bridge := &mqttbridge.Bridge{
+		Client:               c,
+		Broker:               *broker,
+		Prefix:               *prefix,
+		ClientID:             *clientID,
+		Username:             *username,
+		Password:             *password,
+		MaxReconnectInterval: *maxReconnect,
+		Logf: func(format string, args ...any) {
+			fmt.Printf("%s  %s\n", time.Now().Format(monitorTimeFormat), fmt.Sprintf(format, args...))
+		},
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Printf("Bridging %s (baud: %d) to %s with prefix %s\n", c.PortName, c.BaudRate, *broker, bridge.Prefix)
+	fmt.Println("Press Ctrl-C to stop...")
+
+	err = bridge.Run(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		c.Close()
+		os.Exit(1)
+	}
+}
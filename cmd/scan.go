@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/internal/client"
+)
+
+// scanDevice scan --json 输出的设备信息，字段与 serve 的 /api/device 一致
+type scanDevice struct {
+	Port            string `json:"port"`
+	BaudRate        int    `json:"baud_rate"`
+	Identity        string `json:"identity,omitempty"` // USB 转串口的身份，可作为 --port 的值
+	Type            int    `json:"type"`
+	Address         string `json:"address"`
+	Model           string `json:"model"`
+	FirmwareVersion int    `json:"firmware_version"`
+	MCUNumber       int    `json:"mcu_number"`
+	SerialNumber    string `json:"serial_number"`
+	FlashSize       int    `json:"flash_size"`
+}
+
+func handleScan(args []string) {
+	fs := flag.NewFlagSet("scan", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output devices as a JSON array")
+	usbOnly := fs.Bool("usb-only", false, "Only probe USB serial adapters")
+	timeout := fs.Duration("timeout", 100*time.Millisecond, "Probe timeout per port and baud rate")
+
+	fs.Parse(args)
+
+	detector := &client.Detector{
+		Timeout: *timeout,
+		USBOnly: *usbOnly,
+	}
+
+	devices, err := detector.Detect(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error scanning ports: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *jsonOutput {
+		out := make([]scanDevice, 0, len(devices))
+		for _, device := range devices {
+			out = append(out, newScanDevice(device))
+		}
+
+		data, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	if len(devices) == 0 {
+		fmt.Println("No TJC devices found!")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PORT\tBAUD\tMODEL\tFIRMWARE\tSERIAL NUMBER\tFLASH")
+	for _, device := range devices {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%d\t%s\t%s\n",
+			device.PortName,
+			device.BaudRate,
+			device.Info.Model,
+			device.Info.FirmwareVersion,
+			device.Info.Number,
+			formatBytes(int64(device.Info.FlashSize)),
+		)
+	}
+	w.Flush()
+
+	fmt.Printf("\n%d device(s) found\n", len(devices))
+}
+
+func newScanDevice(device *client.DetectedDevice) scanDevice {
+	out := scanDevice{
+		Port:            device.PortName,
+		BaudRate:        device.BaudRate,
+		Type:            device.Info.Type,
+		Address:         device.Info.Address,
+		Model:           device.Info.Model,
+		FirmwareVersion: device.Info.FirmwareVersion,
+		MCUNumber:       device.Info.MainControlChipNumber,
+		SerialNumber:    device.Info.Number,
+		FlashSize:       device.Info.FlashSize,
+	}
+	if device.Port != nil && device.Port.IsUSB {
+		out.Identity = client.USBIdentity(device.Port)
+	}
+
+	return out
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/internal/client"
+	"github.com/blue-cloud-net/tjc-serial-display/internal/httpapi"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/models"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/remote"
+	"google.golang.org/grpc"
+)
+
+const defaultHTTPAddr = ":8080"
+
+func handleServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	port := fs.String("port", "", "Serial port path")
+	portShort := fs.String("p", "", "Serial port path (short)")
+	baud := fs.Int("baud", defaultBaudRate, "Baud rate")
+	baudShort := fs.Int("b", defaultBaudRate, "Baud rate (short)")
+	auto := fs.Bool("auto", false, "Auto detect serial port")
+	autoShort := fs.Bool("a", false, "Auto detect serial port (short)")
+	httpAddr := fs.String("http", defaultHTTPAddr, "HTTP address to listen on, empty to disable")
+	grpcAddr := fs.String("grpc", "", "gRPC address to listen on, empty to disable")
+
+	fs.Parse(args)
+
+	portName := getStringFlag(*port, *portShort)
+	baudRate := getIntFlag(*baud, *baudShort, defaultBaudRate)
+	autoDetect := *auto || *autoShort
+
+	if portName == "" && !autoDetect {
+		autoDetect = true
+	}
+
+	if portName != "" && autoDetect {
+		fmt.Fprintf(os.Stderr, "Error: --port and --auto cannot be used together\n")
+		os.Exit(1)
+	}
+
+	if *httpAddr == "" && *grpcAddr == "" {
+		fmt.Fprintf(os.Stderr, "Error: at least one of --http and --grpc is required\n")
+		os.Exit(1)
+	}
+
+	var c *client.TjcDisplayClient
+
+	if autoDetect {
+		var err error
+		c, err = autoDetectDevice()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	} else {
+		c = &client.TjcDisplayClient{
+			PortName: portName,
+			BaudRate: baudRate,
+		}
+	}
+	c.AutoReconnect = true
+	defer c.Close()
+
+	err := c.Open()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening port: %v\n", err)
+		os.Exit(1)
+	}
+
+	unsubscribe := c.SubscribeConnection(func(event *models.ConnectionEvent) {
+		fmt.Printf("%s  %s: %s\n", event.Time.Format(monitorTimeFormat), c.PortName, event)
+	})
+	defer unsubscribe()
+
+	errs := make(chan error, 2)
+	var httpServer *http.Server
+	var grpcServer *grpc.Server
+
+	if *httpAddr != "" {
+		api := &httpapi.Server{Client: c}
+		httpServer = &http.Server{
+			Addr:              *httpAddr,
+			Handler:           api.Handler(),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		go func() {
+			err := httpServer.ListenAndServe()
+			if err != http.ErrServerClosed {
+				errs <- err
+			}
+		}()
+		fmt.Printf("Serving %s (baud: %d) on http://%s/api\n", c.PortName, c.BaudRate, *httpAddr)
+	}
+
+	if *grpcAddr != "" {
+		listener, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error listening on %s: %v\n", *grpcAddr, err)
+			os.Exit(1)
+		}
+
+		grpcServer = grpc.NewServer()
+		remote.NewServer(c).Register(grpcServer)
+
+		go func() {
+			errs <- grpcServer.Serve(listener)
+		}()
+		fmt.Printf("Serving %s (baud: %d) on grpc %s\n", c.PortName, c.BaudRate, listener.Addr())
+	}
+
+	fmt.Println("Press Ctrl-C to stop...")
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	select {
+	case <-signals:
+	case err = <-errs:
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	}
+
+	// SSE 和订阅流不会自行结束，等待片刻后强制关闭
+	if httpServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		if httpServer.Shutdown(ctx) != nil {
+			httpServer.Close()
+		}
+		cancel()
+	}
+	if grpcServer != nil {
+		grpcServer.Stop()
+	}
+
+	if err != nil {
+		c.Close()
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/internal/client"
+	"github.com/blue-cloud-net/tjc-serial-display/internal/serial"
+)
+
+const defaultListenAddr = ":4001"
+
+func handleServePort(args []string) {
+	fs := flag.NewFlagSet("serve-port", flag.ExitOnError)
+	port := fs.String("port", "", "Serial port path")
+	portShort := fs.String("p", "", "Serial port path (short)")
+	baud := fs.Int("baud", defaultBaudRate, "Baud rate")
+	baudShort := fs.Int("b", defaultBaudRate, "Baud rate (short)")
+	auto := fs.Bool("auto", false, "Auto detect serial port")
+	autoShort := fs.Bool("a", false, "Auto detect serial port (short)")
+	listen := fs.String("listen", defaultListenAddr, "TCP address to listen on")
+	rfc2217 := fs.Bool("rfc2217", false, "Speak RFC 2217 so clients can change serial settings")
+	writerIdle := fs.Duration("writer-idle", 2*time.Second, "Idle time before another client may write")
+
+	fs.Parse(args)
+
+	portName := getStringFlag(*port, *portShort)
+	baudRate := getIntFlag(*baud, *baudShort, defaultBaudRate)
+	autoDetect := *auto || *autoShort
+
+	if portName == "" && !autoDetect {
+		autoDetect = true
+	}
+
+	if portName != "" && autoDetect {
+		fmt.Fprintf(os.Stderr, "Error: --port and --auto cannot be used together\n")
+		os.Exit(1)
+	}
+
+	if autoDetect {
+		c, err := autoDetectDevice()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		portName, baudRate = c.PortName, c.BaudRate
+		c.Close()
+	}
+
+	// 共享的是串口本身，设备身份需要先解析为串口路径
+	if client.IsIdentity(portName) {
+		resolution, err := client.DefaultResolver.Resolve(context.Background(), portName)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		portName = resolution.PortName
+		if resolution.BaudRate != 0 {
+			baudRate = resolution.BaudRate
+		}
+	}
+
+	// 读超时只影响退出时的等待时间，数据到达时会立即返回
+	spm := &serial.SerialPortManager{
+		PortName: portName,
+		BaudRate: baudRate,
+		Timeout:  100 * time.Millisecond,
+	}
+	err := spm.Open()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening port: %v\n", err)
+		os.Exit(1)
+	}
+	defer spm.Close()
+
+	listener, err := net.Listen("tcp", *listen)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error listening on %s: %v\n", *listen, err)
+		os.Exit(1)
+	}
+
+	server := &serial.PortServer{
+		Manager:    spm,
+		RFC2217:    *rfc2217,
+		WriterIdle: *writerIdle,
+		OnClient: func(addr net.Addr, connected bool) {
+			if connected {
+				fmt.Printf("%s  client connected: %s\n", time.Now().Format(monitorTimeFormat), addr)
+			} else {
+				fmt.Printf("%s  client disconnected: %s\n", time.Now().Format(monitorTimeFormat), addr)
+			}
+		},
+	}
+
+	scheme := serial.SchemeTCP
+	if *rfc2217 {
+		scheme = serial.SchemeRFC2217
+	}
+	fmt.Printf("Serving %s (baud: %d) on %s://%s, press Ctrl-C to stop...\n", portName, baudRate, scheme, listener.Addr())
+
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		<-signals
+		server.Close()
+	}()
+
+	err = server.Serve(listener)
+	if err != nil && err != serial.ErrServerClosed {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		spm.Close()
+		os.Exit(1)
+	}
+}
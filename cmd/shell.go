@@ -0,0 +1,337 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/blue-cloud-net/tjc-serial-display/internal/client"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/models"
+	"golang.org/x/term"
+)
+
+const (
+	shellPrompt      = "tjc> "
+	shellHistoryFile = ".tjc_history"
+	shellHistorySize = 500
+)
+
+func handleShell(args []string) {
+	err := runShellCommand(args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runShellCommand 运行 shell 命令，错误通过返回值交给调用方，确保退出前恢复 bkcmd 并关闭串口
+func runShellCommand(args []string) error {
+	fs := flag.NewFlagSet("shell", flag.ExitOnError)
+	port := fs.String("port", "", "Serial port path")
+	portShort := fs.String("p", "", "Serial port path (short)")
+	baud := fs.Int("baud", defaultBaudRate, "Baud rate")
+	baudShort := fs.Int("b", defaultBaudRate, "Baud rate (short)")
+	auto := fs.Bool("auto", false, "Auto detect serial port")
+	autoShort := fs.Bool("a", false, "Auto detect serial port (short)")
+
+	fs.Parse(args)
+
+	portName := getStringFlag(*port, *portShort)
+	baudRate := getIntFlag(*baud, *baudShort, defaultBaudRate)
+	autoDetect := *auto || *autoShort
+
+	if portName == "" && !autoDetect {
+		autoDetect = true
+	}
+
+	if portName != "" && autoDetect {
+		return errors.New("--port and --auto cannot be used together")
+	}
+
+	var c *client.TjcDisplayClient
+
+	if autoDetect {
+		var err error
+		c, err = autoDetectDevice()
+		if err != nil {
+			return err
+		}
+	} else {
+		c = &client.TjcDisplayClient{
+			PortName: portName,
+			BaudRate: baudRate,
+		}
+	}
+	defer c.Close()
+
+	info, err := c.GetDeviceInfo()
+	if err != nil {
+		return fmt.Errorf("connecting to device: %w", err)
+	}
+
+	// 会话中使用 bkcmd=3，每条指令都能看到执行结果，退出时恢复设备进入 shell 前的 bkcmd
+	bkcmd, err := c.GetNumber("bkcmd")
+	if err != nil {
+		return fmt.Errorf("reading bkcmd: %w", err)
+	}
+	previous := client.ReturnMode(bkcmd)
+	if previous != client.ReturnAll {
+		err = c.SetReturnMode(client.ReturnAll)
+		if err != nil {
+			return err
+		}
+	}
+	defer func() {
+		if c.ReturnMode() != previous {
+			_ = c.SetReturnMode(previous)
+		}
+	}()
+
+	fmt.Printf("Connected to %s on %s (baud: %d)\n", info.Model, c.PortName, c.BaudRate)
+	fmt.Println("Type \"help\" for shell commands, \"exit\" or Ctrl-D to quit.")
+
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		return runInteractiveShell(c, fd)
+	}
+
+	return runShell(c, bufio.NewScanner(os.Stdin), os.Stdout)
+}
+
+// runInteractiveShell 在终端中运行带行编辑和历史记录的交互模式
+func runInteractiveShell(c *client.TjcDisplayClient, fd int) error {
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer term.Restore(fd, oldState)
+
+	t := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{os.Stdin, os.Stdout}, shellPrompt)
+
+	if width, height, err := term.GetSize(fd); err == nil {
+		t.SetSize(width, height)
+	}
+
+	history := loadShellHistory()
+	t.History = history
+	defer history.save()
+
+	// 终端输出会在提示符上方插入事件并重绘当前输入行
+	unsubscribe := c.Subscribe(func(event *models.Event) {
+		fmt.Fprintf(t, "<- %s  %s\n", event.Time.Format("15:04:05.000"), event)
+	})
+	defer unsubscribe()
+
+	for {
+		line, err := t.ReadLine()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if !runShellLine(c, line, t) {
+			return nil
+		}
+	}
+}
+
+// runShell 逐行执行输入中的指令，用于管道或脚本输入
+func runShell(c *client.TjcDisplayClient, scanner *bufio.Scanner, w io.Writer) error {
+	unsubscribe := c.Subscribe(func(event *models.Event) {
+		fmt.Fprintf(w, "<- %s  %s\n", event.Time.Format("15:04:05.000"), event)
+	})
+	defer unsubscribe()
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		fmt.Fprintf(w, "%s%s\n", shellPrompt, line)
+
+		if !runShellLine(c, line, w) {
+			return nil
+		}
+	}
+
+	return scanner.Err()
+}
+
+// runShellLine 执行一行输入并输出结果，返回 false 表示退出
+func runShellLine(c *client.TjcDisplayClient, line string, w io.Writer) bool {
+	line = strings.TrimSpace(line)
+
+	switch {
+	case line == "":
+		return true
+	case line == "exit" || line == "quit":
+		return false
+	case line == "help":
+		printShellHelp(w)
+	case line == "info":
+		info, err := c.GetDeviceInfo()
+		if err != nil {
+			printShellError(w, err)
+			break
+		}
+		fmt.Fprintf(w, "=> %s %s, firmware %d, flash %s\n", info.Model, info.Number, info.FirmwareVersion, formatBytes(int64(info.FlashSize)))
+	case line == "sendme":
+		page, err := c.GetPage()
+		if err != nil {
+			printShellError(w, err)
+			break
+		}
+		fmt.Fprintf(w, "=> page %d\n", page)
+	case strings.HasPrefix(line, "print "):
+		result, err := c.Prints(strings.TrimPrefix(line, "print "))
+		if err != nil {
+			printShellError(w, err)
+			break
+		}
+		fmt.Fprintf(w, "=> %q\n", result)
+	case strings.HasPrefix(line, "get "):
+		value, err := c.Get(strings.TrimPrefix(line, "get "))
+		if err != nil {
+			printShellError(w, err)
+			break
+		}
+		fmt.Fprintf(w, "=> %#v\n", value)
+	default:
+		result, err := c.ExecuteCommand(line)
+		if err != nil {
+			printShellError(w, err)
+			break
+		}
+		if len(result) == 0 {
+			fmt.Fprintln(w, "=> (no response)")
+			break
+		}
+		fmt.Fprintf(w, "=> %s\n", describeFrame(result))
+	}
+
+	return true
+}
+
+// describeFrame 将一帧设备返回解析为可读描述
+func describeFrame(frame []byte) string {
+	resp, err := client.ParseResponse(frame)
+	if err != nil {
+		return fmt.Sprintf("% X", frame)
+	}
+
+	switch resp.Type {
+	case client.ResponseTypeSuccess:
+		return "OK"
+	case client.ResponseTypeError:
+		return resp.Err().Error()
+	case client.ResponseTypeEvent:
+		if event, ok := resp.Event(); ok {
+			return event.String()
+		}
+	case client.ResponseTypeData:
+		if value, err := resp.Value(); err == nil {
+			return fmt.Sprintf("%#v", value)
+		}
+	}
+
+	return fmt.Sprintf("% X", resp.RawData)
+}
+
+func printShellError(w io.Writer, err error) {
+	var tjcErr *client.TjcError
+	if errors.As(err, &tjcErr) {
+		fmt.Fprintf(w, "!! %s\n", tjcErr)
+		return
+	}
+
+	fmt.Fprintf(w, "!! Error: %v\n", err)
+}
+
+func printShellHelp(w io.Writer) {
+	fmt.Fprintln(w, "Shell Commands:")
+	fmt.Fprintln(w, "  help                Show this help")
+	fmt.Fprintln(w, "  info                Show device information")
+	fmt.Fprintln(w, "  exit, quit          Leave the shell (or press Ctrl-D)")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Any other line is sent to the device as a TJC instruction:")
+	fmt.Fprintln(w, "  get <target>        Decoded string or number, e.g. get t0.txt")
+	fmt.Fprintln(w, "  print <target>      Raw print output")
+	fmt.Fprintln(w, "  sendme              Current page ID")
+	fmt.Fprintln(w, "  page 1, vis b0,0 ... Result or error code of the instruction")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Device events are shown as they arrive, prefixed with <-.")
+}
+
+// shellHistory 保存在用户主目录下的输入历史，最新的记录在末尾
+type shellHistory struct {
+	path    string
+	entries []string
+}
+
+func loadShellHistory() *shellHistory {
+	history := &shellHistory{}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return history
+	}
+	history.path = filepath.Join(home, shellHistoryFile)
+
+	data, err := os.ReadFile(history.path)
+	if err != nil {
+		return history
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if line != "" {
+			history.entries = append(history.entries, line)
+		}
+	}
+	history.trim()
+
+	return history
+}
+
+func (h *shellHistory) Add(entry string) {
+	entry = strings.TrimSpace(entry)
+	if entry == "" {
+		return
+	}
+
+	// 与上一条相同时不重复记录
+	if len(h.entries) > 0 && h.entries[len(h.entries)-1] == entry {
+		return
+	}
+
+	h.entries = append(h.entries, entry)
+	h.trim()
+}
+
+func (h *shellHistory) Len() int {
+	return len(h.entries)
+}
+
+func (h *shellHistory) At(idx int) string {
+	return h.entries[len(h.entries)-1-idx]
+}
+
+func (h *shellHistory) trim() {
+	if len(h.entries) > shellHistorySize {
+		h.entries = h.entries[len(h.entries)-shellHistorySize:]
+	}
+}
+
+func (h *shellHistory) save() {
+	if h.path == "" || len(h.entries) == 0 {
+		return
+	}
+
+	_ = os.WriteFile(h.path, []byte(strings.Join(h.entries, "\n")+"\n"), 0o600)
+}
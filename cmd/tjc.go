@@ -1,16 +1,17 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
-	"time"
 
 	"github.com/blue-cloud-net/tjc-serial-display/internal/client"
 	"github.com/blue-cloud-net/tjc-serial-display/internal/serial"
-	"github.com/blue-cloud-net/tjc-serial-display/pkg/consts"
 	"github.com/blue-cloud-net/tjc-serial-display/pkg/models"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/tft"
 )
 
 const (
@@ -24,16 +25,33 @@ func main() {
 	}
 
 	command := os.Args[1]
+	initResolver()
 
 	switch command {
 	case "list-ports":
 		handleListPorts()
+	case "scan":
+		handleScan(os.Args[2:])
 	case "info":
 		handleInfo(os.Args[2:])
 	case "exec":
 		handleExec(os.Args[2:])
+	case "baud":
+		handleBaud(os.Args[2:])
 	case "upgrade":
 		handleUpgrade(os.Args[2:])
+	case "tft-info":
+		handleTftInfo(os.Args[2:])
+	case "shell":
+		handleShell(os.Args[2:])
+	case "monitor":
+		handleMonitor(os.Args[2:])
+	case "serve-port":
+		handleServePort(os.Args[2:])
+	case "serve":
+		handleServe(os.Args[2:])
+	case "mqtt":
+		handleMQTT(os.Args[2:])
 	case "help":
 		if len(os.Args) > 2 {
 			printCommandHelp(os.Args[2])
@@ -47,8 +65,18 @@ func main() {
 	}
 }
 
+// initResolver 将设备身份与端口的映射缓存到用户缓存目录，下次按身份打开时无需重新探测
+func initResolver() {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return
+	}
+
+	client.DefaultResolver.CachePath = filepath.Join(dir, "tjc-serial-display", "identities.json")
+}
+
 func handleListPorts() {
-	ports, err := serial.ListPorts()
+	ports, err := serial.ListPortDetails()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error listing ports: %v\n", err)
 		os.Exit(1)
@@ -61,7 +89,12 @@ func handleListPorts() {
 
 	fmt.Println("Available serial ports:")
 	for _, port := range ports {
-		fmt.Printf("  %s\n", port)
+		// USB 转串口同时显示其身份，可用作 --port 的值
+		if port.IsUSB {
+			fmt.Printf("  %-16s %s\n", port.Name, client.USBIdentity(port))
+		} else {
+			fmt.Printf("  %s\n", port.Name)
+		}
 	}
 }
 
@@ -173,6 +206,14 @@ func handleExec(args []string) {
 			os.Exit(1)
 		}
 		fmt.Println(result)
+	} else if strings.HasPrefix(cmdString, "get ") {
+		// get 命令返回字符串（0x70）或数值（0x71）
+		value, err := c.Get(strings.TrimPrefix(cmdString, "get "))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error executing command: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(value)
 	} else if cmdString == "sendme" {
 		// sendme 返回当前页面
 		page, err := c.GetPage()
@@ -195,7 +236,7 @@ func handleExec(args []string) {
 func handleUpgrade(args []string) {
 	if len(args) < 1 {
 		fmt.Fprintf(os.Stderr, "Error: upgrade command requires a TFT file path\n")
-		fmt.Fprintf(os.Stderr, "Usage: tjs-serial-display upgrade <tft_file> [-p|--port <port>] [-b|--baud <rate>] [-a|--auto]\n")
+		fmt.Fprintf(os.Stderr, "Usage: tjs-serial-display upgrade <tft_file> [-p|--port <port>] [-b|--baud <rate>] [-a|--auto] [-f|--force]\n")
 		os.Exit(1)
 	}
 
@@ -207,6 +248,8 @@ func handleUpgrade(args []string) {
 	baudShort := fs.Int("b", defaultBaudRate, "Baud rate (short)")
 	auto := fs.Bool("auto", false, "Auto detect serial port")
 	autoShort := fs.Bool("a", false, "Auto detect serial port (short)")
+	force := fs.Bool("force", false, "Skip the TFT model check")
+	forceShort := fs.Bool("f", false, "Skip the TFT model check (short)")
 
 	fs.Parse(args[1:])
 
@@ -243,16 +286,19 @@ func handleUpgrade(args []string) {
 
 	fmt.Printf("Upgrading device with file: %s\n", tftFile)
 
-	err := c.Upgrade(tftFile, 0, func(progress *models.UpgradeProgress) {
-		bar := progressBar(progress.Percentage, 50)
-		speed := formatBytes(progress.Speed)
-		fmt.Printf("\r[%s] %.1f%% (%s/%s) %s/s",
-			bar,
-			progress.Percentage,
-			formatBytes(progress.Current),
-			formatBytes(progress.Total),
-			speed,
-		)
+	err := c.UpgradeWithOptions(tftFile, &models.UpgradeOptions{
+		Force: *force || *forceShort,
+		Progress: func(progress *models.UpgradeProgress) {
+			bar := progressBar(progress.Percentage, 50)
+			speed := formatBytes(progress.Speed)
+			fmt.Printf("\r[%s] %.1f%% (%s/%s) %s/s",
+				bar,
+				progress.Percentage,
+				formatBytes(progress.Current),
+				formatBytes(progress.Total),
+				speed,
+			)
+		},
 	})
 
 	if err != nil {
@@ -263,49 +309,59 @@ func handleUpgrade(args []string) {
 	fmt.Println("\nUpgrade completed successfully!")
 }
 
-// autoDetectDevice 自动检测并连接设备
-func autoDetectDevice() (*client.TjcDisplayClient, error) {
-	ports, err := serial.ListPorts()
+func handleTftInfo(args []string) {
+	if len(args) < 1 {
+		fmt.Fprintf(os.Stderr, "Error: tft-info command requires a TFT file path\n")
+		fmt.Fprintf(os.Stderr, "Usage: tjs-serial-display tft-info <tft_file>\n")
+		os.Exit(1)
+	}
+
+	f, err := os.Open(args[0])
 	if err != nil {
-		return nil, fmt.Errorf("failed to list ports: %w", err)
+		fmt.Fprintf(os.Stderr, "Error opening file: %v\n", err)
+		os.Exit(1)
 	}
+	defer f.Close()
 
-	if len(ports) == 0 {
-		return nil, fmt.Errorf("no serial ports found")
+	stat, err := f.Stat()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
+		os.Exit(1)
 	}
 
-	fmt.Println("Auto detecting TJC device...")
-	// 遍历所有端口和所有波特率
-	for _, port := range ports {
-		for _, baudRate := range consts.SupportedBaudrate {
-			c := &client.TjcDisplayClient{
-				PortName: port,
-				BaudRate: baudRate,
-				Timeout:  100 * time.Millisecond,
-			}
-
-			fmt.Printf("Trying %s @ %d baud...\n", port, baudRate)
-
-			// 尝试连接并获取设备信息
-			_, err := c.GetDeviceInfo()
-			if err == nil {
-				fmt.Println() // 换行
-
-				c.Close()
-				c = &client.TjcDisplayClient{
-					PortName: port,
-					BaudRate: baudRate,
-				}
-
-				return c, nil
-			}
-			c.Close()
+	program, err := tft.Open(f, stat.Size())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing TFT file: %v\n", err)
+		os.Exit(1)
+	}
 
-		}
+	fmt.Println("TFT File Information:")
+	fmt.Printf("  Model:            %s\n", program.Model)
+	fmt.Printf("  File Size:        %s\n", formatBytes(program.FileSize))
+}
+
+// autoDetectDevice 自动检测设备，找到多个设备时使用第一个（按串口路径排序）
+func autoDetectDevice() (*client.TjcDisplayClient, error) {
+	fmt.Fprintln(os.Stderr, "Auto detecting TJC device...")
+
+	devices, err := (&client.Detector{}).Detect(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect devices: %w", err)
+	}
+
+	if len(devices) == 0 {
+		return nil, fmt.Errorf("no TJC device found on any port with any supported baud rate")
+	}
+
+	device := devices[0]
+	if len(devices) > 1 {
+		fmt.Fprintf(os.Stderr, "Found %d devices, using %s @ %d baud (specify --port to choose)\n", len(devices), device.PortName, device.BaudRate)
 	}
 
-	fmt.Println() // 换行
-	return nil, fmt.Errorf("no TJC device found on any port with any supported baud rate")
+	return &client.TjcDisplayClient{
+		PortName: device.PortName,
+		BaudRate: device.BaudRate,
+	}, nil
 }
 
 // 辅助函数
@@ -377,21 +433,39 @@ func printUsage() {
 	fmt.Println()
 	fmt.Println("Available Commands:")
 	fmt.Println("  list-ports          List all available serial ports")
+	fmt.Println("  scan                Find all TJC devices on all ports")
 	fmt.Println("  info                Get device information")
 	fmt.Println("  exec <command>      Execute TJC command")
+	fmt.Println("  baud <rate>         Change the device baud rate")
 	fmt.Println("  upgrade <file>      Upgrade device firmware")
+	fmt.Println("  tft-info <file>     Show TFT file information")
+	fmt.Println("  shell               Interactive instruction shell")
+	fmt.Println("  monitor             Print decoded device traffic")
+	fmt.Println("  serve-port          Share the serial port over TCP")
+	fmt.Println("  serve               HTTP/JSON and gRPC control API")
+	fmt.Println("  mqtt                Bridge the device to an MQTT broker")
 	fmt.Println("  help [command]      Show help for a command")
 	fmt.Println()
 	fmt.Println("Global Options:")
-	fmt.Println("  -p, --port <name>   Serial port path")
+	fmt.Println("  -p, --port <name>   Serial port path, or device identity")
+	fmt.Println("                      (serial:<number>, usb:<vid>:<pid>[:<serial>])")
 	fmt.Println("  -b, --baud <rate>   Baud rate (default: 115200)")
 	fmt.Println("  -a, --auto          Auto detect serial port")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  tjs-serial-display list-ports")
+	fmt.Println("  tjs-serial-display scan --json")
 	fmt.Println("  tjs-serial-display info --auto")
+	fmt.Println("  tjs-serial-display info -p serial:D264B8204F0E1828")
 	fmt.Println("  tjs-serial-display exec \"page 2\" -p /dev/ttyUSB0")
+	fmt.Println("  tjs-serial-display baud 921600 --save -p /dev/ttyUSB0")
 	fmt.Println("  tjs-serial-display upgrade program.tft --auto")
+	fmt.Println("  tjs-serial-display tft-info program.tft")
+	fmt.Println("  tjs-serial-display shell -p /dev/ttyUSB0")
+	fmt.Println("  tjs-serial-display monitor -p /dev/ttyUSB0 --json")
+	fmt.Println("  tjs-serial-display serve-port -p /dev/ttyUSB0 --listen :4001 --rfc2217")
+	fmt.Println("  tjs-serial-display serve -p /dev/ttyUSB0 --http :8080 --grpc :9090")
+	fmt.Println("  tjs-serial-display mqtt -p /dev/ttyUSB0 --broker tcp://localhost:1883 --prefix plant/line1/hmi")
 	fmt.Println()
 	fmt.Println("For more information, use: tjs-serial-display help <command>")
 }
@@ -402,6 +476,18 @@ func printCommandHelp(command string) {
 		fmt.Println("Usage: tjs-serial-display list-ports")
 		fmt.Println()
 		fmt.Println("List all available serial ports on the system.")
+		fmt.Println("USB serial adapters are shown with their usb:<vid>:<pid>[:<serial>] identity,")
+		fmt.Println("which can be passed to --port and stays the same when the port is renamed.")
+	case "scan":
+		fmt.Println("Usage: tjs-serial-display scan [--json] [--usb-only] [--timeout <duration>]")
+		fmt.Println()
+		fmt.Println("Probe all serial ports concurrently and list every TJC device found,")
+		fmt.Println("with its port, baud rate, model, firmware, serial number and flash size.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fmt.Println("  --json                Output devices as a JSON array")
+		fmt.Println("  --usb-only            Only probe USB serial adapters")
+		fmt.Println("  --timeout <duration>  Probe timeout per port and baud rate (default: 100ms)")
 	case "info":
 		fmt.Println("Usage: tjs-serial-display info [-p|--port <port>] [-b|--baud <rate>] [-a|--auto]")
 		fmt.Println()
@@ -419,6 +505,7 @@ func printCommandHelp(command string) {
 		fmt.Println("Common Commands:")
 		fmt.Println("  page <id>                 Jump to page")
 		fmt.Println("  sendme                    Get current page ID")
+		fmt.Println("  get <target>              Get target value (decoded string or number)")
 		fmt.Println("  print <target>            Print target value")
 		fmt.Println("  <comp>.txt=\"value\"        Set text value")
 		fmt.Println("  vis <comp>,0              Hide component")
@@ -430,17 +517,117 @@ func printCommandHelp(command string) {
 		fmt.Println("  -p, --port <name>   Serial port path")
 		fmt.Println("  -b, --baud <rate>   Baud rate (default: 115200)")
 		fmt.Println("  -a, --auto          Auto detect device")
+	case "baud":
+		fmt.Println("Usage: tjs-serial-display baud <rate> [--save] [-p|--port <port>] [-b|--baud <rate>] [-a|--auto]")
+		fmt.Println()
+		fmt.Println("Change the device baud rate and verify the connection at the new rate.")
+		fmt.Println("If the device does not respond at the new rate, both sides are restored.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fmt.Println("  -p, --port <name>   Serial port path")
+		fmt.Println("  -b, --baud <rate>   Current baud rate (default: 115200)")
+		fmt.Println("  -a, --auto          Auto detect device")
+		fmt.Println("  --save              Also change the power-on default (bauds), otherwise")
+		fmt.Println("                      the device returns to it after a restart (baud)")
 	case "upgrade":
-		fmt.Println("Usage: tjs-serial-display upgrade <tft_file> [-p|--port <port>] [-b|--baud <rate>] [-a|--auto]")
+		fmt.Println("Usage: tjs-serial-display upgrade <tft_file> [-p|--port <port>] [-b|--baud <rate>] [-a|--auto] [-f|--force]")
 		fmt.Println()
 		fmt.Println("Upgrade the device firmware with a TFT file.")
+		fmt.Println("The file's target model is checked against the device before upgrading.")
 		fmt.Println()
 		fmt.Println("Options:")
 		fmt.Println("  -p, --port <name>   Serial port path")
 		fmt.Println("  -b, --baud <rate>   Baud rate (default: 115200)")
 		fmt.Println("  -a, --auto          Auto detect device")
+		fmt.Println("  -f, --force         Skip the check that the file targets the device model")
 		fmt.Println()
 		fmt.Println("Warning: Do not disconnect power during upgrade!")
+	case "tft-info":
+		fmt.Println("Usage: tjs-serial-display tft-info <tft_file>")
+		fmt.Println()
+		fmt.Println("Show the target device model and size of a TFT file.")
+	case "shell":
+		fmt.Println("Usage: tjs-serial-display shell [-p|--port <port>] [-b|--baud <rate>] [-a|--auto]")
+		fmt.Println()
+		fmt.Println("Open the device once and run instructions interactively.")
+		fmt.Println("Supports line editing and history (saved to ~/.tjc_history).")
+		fmt.Println("Responses are decoded, error codes are explained and device")
+		fmt.Println("events are printed as they arrive. Lines can also be piped in.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fmt.Println("  -p, --port <name>   Serial port path")
+		fmt.Println("  -b, --baud <rate>   Baud rate (default: 115200)")
+		fmt.Println("  -a, --auto          Auto detect device")
+	case "monitor":
+		fmt.Println("Usage: tjs-serial-display monitor [-p|--port <port>] [-b|--baud <rate>] [-a|--auto] [--hex] [--json]")
+		fmt.Println()
+		fmt.Println("Attach to the device and print every received frame with a timestamp:")
+		fmt.Println("touch events, coordinates, sleep/wake, startup, data returns and error codes.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fmt.Println("  -p, --port <name>   Serial port path")
+		fmt.Println("  -b, --baud <rate>   Baud rate (default: 115200)")
+		fmt.Println("  -a, --auto          Auto detect device")
+		fmt.Println("  --hex               Append a hex dump of each frame")
+		fmt.Println("  --json              Output one JSON object per line")
+	case "serve-port":
+		fmt.Println("Usage: tjs-serial-display serve-port [-p|--port <port>] [-b|--baud <rate>] [-a|--auto] [--listen <addr>] [--rfc2217] [--writer-idle <duration>]")
+		fmt.Println()
+		fmt.Println("Share one serial port with remote tools over TCP. Data from the device is")
+		fmt.Println("sent to every client; only one client may write at a time, others wait")
+		fmt.Println("until it disconnects or stays idle, so instructions never interleave.")
+		fmt.Println("Remote tools connect with -p tcp://host:port or -p rfc2217://host:port.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fmt.Println("  -p, --port <name>         Serial port path")
+		fmt.Println("  -b, --baud <rate>         Baud rate (default: 115200)")
+		fmt.Println("  -a, --auto                Auto detect device")
+		fmt.Println("  --listen <addr>           TCP address to listen on (default: :4001)")
+		fmt.Println("  --rfc2217                 Let clients change baud rate and line settings (RFC 2217)")
+		fmt.Println("  --writer-idle <duration>  Idle time before another client may write (default: 2s)")
+	case "serve":
+		fmt.Println("Usage: tjs-serial-display serve [-p|--port <port>] [-b|--baud <rate>] [-a|--auto] [--http <addr>] [--grpc <addr>]")
+		fmt.Println()
+		fmt.Println("Keep the device open and expose a REST API for dashboards:")
+		fmt.Println("  GET  /api/device                    Device information")
+		fmt.Println("  GET  /api/page, PUT /api/page       Current page / jump to page {\"page\": 1}")
+		fmt.Println("  GET  /api/components/{name}/{attr}  Read attribute, e.g. /api/components/t0/txt")
+		fmt.Println("  PUT  /api/components/{name}/{attr}  Write attribute {\"value\": \"text\"} or {\"value\": 12}")
+		fmt.Println("  POST /api/instruction               Run raw instruction {\"instruction\": \"vis b0,0\"}")
+		fmt.Println("  POST /api/upgrade                   Upload TFT (multipart field \"file\", optional \"force\")")
+		fmt.Println("  GET  /api/upgrade[/events]          Upgrade status / progress stream (SSE)")
+		fmt.Println("  GET  /api/events                    Touch and device event stream (SSE)")
+		fmt.Println()
+		fmt.Println("With --grpc, also serve tjc.v1.DisplayService (proto/tjc/v1/display.proto).")
+		fmt.Println()
+		fmt.Println("Options:")
+		fmt.Println("  -p, --port <name>   Serial port path")
+		fmt.Println("  -b, --baud <rate>   Baud rate (default: 115200)")
+		fmt.Println("  -a, --auto          Auto detect device")
+		fmt.Println("  --http <addr>       HTTP address to listen on, empty to disable (default: :8080)")
+		fmt.Println("  --grpc <addr>       gRPC address to listen on (default: disabled)")
+	case "mqtt":
+		fmt.Println("Usage: tjs-serial-display mqtt --broker <url> [--prefix <prefix>] [-p|--port <port>] [-b|--baud <rate>] [-a|--auto] [--client-id <id>] [--username <user>] [--password <pass>] [--max-reconnect <duration>]")
+		fmt.Println()
+		fmt.Println("Bridge the device to an MQTT broker. Topics under <prefix>:")
+		fmt.Println("  status                    online/offline (retained, also the last will)")
+		fmt.Println("  device                    Device info and serial connection state (JSON, retained)")
+		fmt.Println("  event/<type>              Latest touch/page/sleep/... event (JSON, retained)")
+		fmt.Println("  set/<component>/<attr>    Set attribute, payload is a string or an integer")
+		fmt.Println("  page                      Jump to page, payload is the page ID")
+		fmt.Println("  exec                      Run raw instruction")
+		fmt.Println("  result                    Result of each command (JSON)")
+		fmt.Println()
+		fmt.Println("Options:")
+		fmt.Println("  --broker <url>              Broker address, e.g. tcp://localhost:1883")
+		fmt.Println("  --prefix <prefix>           Topic prefix (default: tjc)")
+		fmt.Println("  -p, --port <name>           Serial port path")
+		fmt.Println("  -b, --baud <rate>           Baud rate (default: 115200)")
+		fmt.Println("  -a, --auto                  Auto detect device")
+		fmt.Println("  --client-id <id>            MQTT client ID (default: random)")
+		fmt.Println("  --username <user>           MQTT username")
+		fmt.Println("  --password <pass>           MQTT password")
+		fmt.Println("  --max-reconnect <duration>  Maximum interval between broker/serial reconnects (default: 30s)")
 	default:
 		fmt.Printf("Unknown command: %s\n", command)
 		printUsage()
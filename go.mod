@@ -2,8 +2,20 @@ module github.com/blue-cloud-net/tjc-serial-display
 
 go 1.25.4
 
+require (
+	github.com/eclipse/paho.mqtt.golang v1.5.1
+	go.bug.st/serial v1.6.4
+	golang.org/x/term v0.40.0
+	google.golang.org/grpc v1.79.0
+	google.golang.org/protobuf v1.36.12
+)
+
 require (
 	github.com/creack/goselect v0.1.2 // indirect
-	go.bug.st/serial v1.6.4 // indirect
-	golang.org/x/sys v0.19.0 // indirect
+	github.com/gorilla/websocket v1.5.3 // indirect
+	golang.org/x/net v0.48.0 // indirect
+	golang.org/x/sync v0.19.0 // indirect
+	golang.org/x/sys v0.41.0 // indirect
+	golang.org/x/text v0.32.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20251202230838-ff82c1b0f217 // indirect
 )
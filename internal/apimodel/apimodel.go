@@ -0,0 +1,74 @@
+// Package apimodel HTTP API 和 MQTT 桥接共用的 JSON 数据模型和参数校验
+package apimodel
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/models"
+)
+
+// Event 设备事件
+type Event struct {
+	Type      string    `json:"type"`
+	Code      string    `json:"code"`
+	Page      int       `json:"page"`
+	Component int       `json:"component"`
+	Pressed   bool      `json:"pressed"`
+	X         int       `json:"x"`
+	Y         int       `json:"y"`
+	Time      time.Time `json:"time"`
+}
+
+// NewEvent 转换设备事件
+func NewEvent(event *models.Event) *Event {
+	return &Event{
+		Type:      event.Type.String(),
+		Code:      fmt.Sprintf("0x%02X", event.Code),
+		Page:      event.Page,
+		Component: event.Component,
+		Pressed:   event.Pressed,
+		X:         event.X,
+		Y:         event.Y,
+		Time:      event.Time,
+	}
+}
+
+// DeviceInfo 设备信息
+type DeviceInfo struct {
+	Type            int    `json:"type"`
+	Address         string `json:"address"`
+	Model           string `json:"model"`
+	FirmwareVersion int    `json:"firmware_version"`
+	MCUNumber       int    `json:"mcu_number"`
+	SerialNumber    string `json:"serial_number"`
+	FlashSize       int    `json:"flash_size"`
+}
+
+// NewDeviceInfo 转换设备信息
+func NewDeviceInfo(info *models.DeviceInfo) DeviceInfo {
+	return DeviceInfo{
+		Type:            info.Type,
+		Address:         info.Address,
+		Model:           info.Model,
+		FirmwareVersion: info.FirmwareVersion,
+		MCUNumber:       info.MainControlChipNumber,
+		SerialNumber:    info.Number,
+		FlashSize:       info.FlashSize,
+	}
+}
+
+// IsIdentifier 判断是否为合法的属性名（字母、数字、下划线）
+func IsIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, r := range s {
+		if !(r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			return false
+		}
+	}
+
+	return true
+}
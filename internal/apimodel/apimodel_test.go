@@ -0,0 +1,50 @@
+package apimodel
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/models"
+)
+
+func TestIsIdentifier(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected bool
+	}{
+		{"txt", true},
+		{"pco_2", true},
+		{"", false},
+		{"t0.txt", false},
+		{"txt=1", false},
+		{"属性", false},
+	}
+
+	for _, tc := range testCases {
+		if got := IsIdentifier(tc.input); got != tc.expected {
+			t.Errorf("IsIdentifier(%q): expected %v, got %v", tc.input, tc.expected, got)
+		}
+	}
+}
+
+func TestNewEvent(t *testing.T) {
+	event := NewEvent(&models.Event{
+		Type:      models.EventTouch,
+		Code:      0x65,
+		Page:      1,
+		Component: 2,
+		Pressed:   true,
+		Time:      time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	})
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"type":"touch","code":"0x65","page":1,"component":2,"pressed":true,"x":0,"y":0,"time":"2024-01-02T03:04:05Z"}`
+	if string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+}
@@ -0,0 +1,125 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/consts"
+)
+
+// 设备收到 baud/bauds 指令后切换波特率所需的时间
+const baudSwitchDelay = 100 * time.Millisecond
+
+// SetDeviceBaudRate 修改设备波特率，persistent 为 true 时使用 bauds 同时修改上电默认波特率，
+// 否则使用 baud 只修改本次运行的波特率（设备重新上电后恢复）
+func (c *TjcDisplayClient) SetDeviceBaudRate(rate int, persistent bool) error {
+	return c.SetDeviceBaudRateContext(context.Background(), rate, persistent)
+}
+
+// SetDeviceBaudRateContext 修改设备波特率，context 取消或超时时立即返回
+//
+// 设备接受指令后本地串口切换到新波特率并用 connect 验证；切换或验证失败时回滚，
+// 设备和本地串口恢复原波特率，返回的错误说明回滚是否成功。
+func (c *TjcDisplayClient) SetDeviceBaudRateContext(ctx context.Context, rate int, persistent bool) error {
+	if !slices.Contains(consts.SupportedBaudrate, rate) {
+		return fmt.Errorf("baud rate %d is not supported", rate)
+	}
+
+	err := c.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	variable := "baud"
+	if persistent {
+		variable = "bauds"
+	}
+
+	// 设备以原波特率回复，bkcmd 为 0 或 2 时成功不回复
+	_, err = c.sendCommandAndWaitResponse(ctx, fmt.Sprintf("%s=%d", variable, rate), false)
+	if err != nil && !errors.Is(err, errReadTimeout) {
+		return fmt.Errorf("failed to set device baud rate: %w", err)
+	}
+
+	previous := c.BaudRate
+	err = c.switchBaudRate(ctx, rate)
+	if err == nil {
+		return nil
+	}
+
+	rollbackErr := c.rollbackBaudRate(ctx, variable, rate, previous)
+	if rollbackErr != nil {
+		return fmt.Errorf("failed to switch to %d baud: %w (rollback to %d failed: %v)", rate, err, previous, rollbackErr)
+	}
+
+	return fmt.Errorf("failed to switch to %d baud, restored %d: %w", rate, previous, err)
+}
+
+// switchBaudRate 等待设备切换后本地串口切换到新波特率，并用 connect 验证
+func (c *TjcDisplayClient) switchBaudRate(ctx context.Context, rate int) error {
+	select {
+	case <-time.After(baudSwitchDelay):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	err := c.setLocalBaudRate(ctx, rate)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.GetDeviceInfoContext(ctx)
+	if err != nil {
+		return fmt.Errorf("device did not respond at %d baud: %w", rate, err)
+	}
+
+	return nil
+}
+
+// rollbackBaudRate 恢复原波特率：设备没有切换时只需恢复本地串口，
+// 否则以新波特率发送指令让设备切回原波特率
+func (c *TjcDisplayClient) rollbackBaudRate(ctx context.Context, variable string, rate, previous int) error {
+	err := c.setLocalBaudRate(ctx, previous)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.GetDeviceInfoContext(ctx); err == nil {
+		return nil
+	}
+
+	err = c.setLocalBaudRate(ctx, rate)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.sendCommandAndWaitResponse(ctx, fmt.Sprintf("%s=%d", variable, previous), false)
+	if err != nil && !errors.Is(err, errReadTimeout) {
+		return err
+	}
+
+	return c.switchBaudRate(ctx, previous)
+}
+
+// setLocalBaudRate 切换本地串口的波特率，之后重新打开串口（如自动重连）也使用该波特率
+func (c *TjcDisplayClient) setLocalBaudRate(ctx context.Context, rate int) error {
+	err := c.optLock.LockContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.optLock.Unlock()
+
+	c.BaudRate = rate
+	err = c.serialManager.SetBaudRate(rate)
+	if err != nil {
+		return err
+	}
+
+	// 清理切换期间收到的乱码
+	c.discardResponses()
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/blue-cloud-net/tjc-serial-display/internal/serial"
+	"github.com/blue-cloud-net/tjc-serial-display/internal/simulator"
+	goserial "go.bug.st/serial"
+)
+
+// TestTjcDisplayClient_SetDeviceBaudRate 测试修改设备波特率后本地串口同步切换
+func TestTjcDisplayClient_SetDeviceBaudRate(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	client := newSimulatedClient(t, device)
+
+	if err := client.SetDeviceBaudRate(921600, false); err != nil {
+		t.Fatalf("SetDeviceBaudRate failed: %v", err)
+	}
+	if device.BaudRate() != 921600 || client.BaudRate != 921600 {
+		t.Errorf("Expected 921600 baud, device %d, client %d", device.BaudRate(), client.BaudRate)
+	}
+
+	if err := client.SetDeviceBaudRate(9600, true); err != nil {
+		t.Fatalf("SetDeviceBaudRate persistent failed: %v", err)
+	}
+	if device.BaudRate() != 9600 {
+		t.Errorf("Expected 9600 baud, got %d", device.BaudRate())
+	}
+	if _, err := client.GetPage(); err != nil {
+		t.Errorf("GetPage after baud change failed: %v", err)
+	}
+
+	// 不支持的波特率不发送到设备
+	if err := client.SetDeviceBaudRate(1234, false); err == nil {
+		t.Error("Expected error for unsupported baud rate")
+	}
+	if device.BaudRate() != 9600 {
+		t.Errorf("Expected device to stay at 9600, got %d", device.BaudRate())
+	}
+}
+
+// modeFailTransport 第一次切换到指定波特率时失败的传输通道
+type modeFailTransport struct {
+	serial.Transport
+	failRate int
+	failed   *atomic.Bool
+}
+
+func (t *modeFailTransport) SetMode(mode *goserial.Mode) error {
+	if mode.BaudRate == t.failRate && !t.failed.Swap(true) {
+		return errors.New("mode not supported")
+	}
+
+	return t.Transport.SetMode(mode)
+}
+
+// TestTjcDisplayClient_SetDeviceBaudRate_Rollback 测试本地串口切换失败时设备恢复原波特率
+func TestTjcDisplayClient_SetDeviceBaudRate_Rollback(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	client := newSimulatedClient(t, device)
+
+	var failed atomic.Bool
+	open := device.Opener()
+	client.Opener = func(portName string, mode *goserial.Mode) (serial.Transport, error) {
+		transport, err := open(portName, mode)
+		if err != nil {
+			return nil, err
+		}
+		return &modeFailTransport{Transport: transport, failRate: 921600, failed: &failed}, nil
+	}
+
+	err := client.SetDeviceBaudRate(921600, false)
+	if err == nil {
+		t.Fatal("Expected error when local switch fails")
+	}
+	if device.BaudRate() != 115200 || client.BaudRate != 115200 {
+		t.Errorf("Expected rollback to 115200, device %d, client %d", device.BaudRate(), client.BaudRate)
+	}
+	if _, err := client.GetPage(); err != nil {
+		t.Errorf("GetPage after rollback failed: %v", err)
+	}
+
+	// 设备已切换到新波特率，回滚时以新波特率发送原波特率
+	if !slices.Contains(device.History(), "baud=115200") {
+		t.Errorf("Expected baud=115200 in history, got %v", device.History())
+	}
+}
@@ -1,13 +1,15 @@
 package client
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
-	"io"
-	"os"
 	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/blue-cloud-net/tjc-serial-display/internal/serial"
@@ -24,24 +26,67 @@ var (
 	EndSymbol = []byte(EndStr)
 )
 
+// exitActiveParse 退出主动解析模式的指令
+const exitActiveParse = "DRAKJHSUYDGBNCJHGJKSHBDN"
+
 type TjcDisplayClient struct {
-	PortName      string
+	PortName      string // 串口路径，tcp://host:port、rfc2217://host:port 网络串口，或 serial:、usb: 设备身份
 	BaudRate      int
 	Timeout       time.Duration
+	Opener        serial.Opener // 自定义传输通道，为空时使用系统串口
+	Resolver      *Resolver     // 解析设备身份，为空时使用 DefaultResolver
 	serialManager *serial.SerialPortManager
-	optLock       sync.Mutex
+	optLock       ctxLock
+	closed        atomic.Bool // 已调用 Close，再次 Open 时需要重新打开串口
+
+	// 设备断开（如 USB 转串口被拔出）后在后台按退避间隔重新打开串口，
+	// 连接状态变化通过 SubscribeConnection 通知
+	AutoReconnect        bool
+	MaxReconnectInterval time.Duration // 自动重连的最长间隔，默认 10s
+	reconnectLock        sync.Mutex
+	reconnectCancel      context.CancelFunc
+	reconnectDone        chan struct{}
+	disconnected         atomic.Bool // 设备已断开，尚未重新连接
+
+	// 睡眠时设备忽略大部分指令，没有返回时报告 ErrSleeping；开启 AutoWake 时发送指令前先唤醒设备
+	AutoWake bool
+	sleeping atomic.Bool
+
+	// 返回方式（bkcmd）加 1，0 表示尚未得知；explicitMode 表示由调用方指定，打开串口时设置到设备，
+	// 否则打开串口时读取设备当前的 bkcmd，不改变设备状态
+	returnMode   atomic.Int32
+	explicitMode atomic.Bool
+
+	// 被动模式：打开串口时不发送退出主动解析和 bkcmd 等初始化指令，不改变设备状态，用于只监听通信流量
+	Passive bool
+
+	// 后台读取协程
+	readerStop chan struct{}
+	readerDone chan struct{}
+	readErr    atomic.Pointer[error]
+	responses  chan []byte
+	pending    atomic.Bool
+
+	// 事件订阅
+	subLock          sync.Mutex
+	subscribers      map[int]models.EventCallback
+	frameSubscribers map[int]func(frame []byte)
+	connSubscribers  map[int]models.ConnectionCallback
+	nextSubID        int
 }
 
-func (c *TjcDisplayClient) connect() error {
-	ports, err := serial.ListPorts()
-	if err != nil {
-		return err
-	}
+func (c *TjcDisplayClient) connect(ctx context.Context) error {
+	// 使用系统串口时检查是否存在指定的串口（设备身份在解析时已确认）
+	if c.Opener == nil && !serial.IsNetworkPort(c.PortName) && !IsIdentity(c.PortName) {
+		ports, err := serial.ListPorts()
+		if err != nil {
+			return err
+		}
 
-	// 检查是否存在指定的串口
-	found := slices.Contains(ports, c.PortName)
-	if !found {
-		return fmt.Errorf("serial port %s not found", c.PortName)
+		found := slices.Contains(ports, c.PortName)
+		if !found {
+			return fmt.Errorf("serial port %s not found", c.PortName)
+		}
 	}
 
 	// 检查波特率是否支持
@@ -50,41 +95,103 @@ func (c *TjcDisplayClient) connect() error {
 		return fmt.Errorf("baud rate %d is not supported", c.BaudRate)
 	}
 
+	opened := false
 	if c.serialManager == nil {
+		portName, err := c.resolvePort(ctx)
+		if err != nil {
+			return err
+		}
+
 		manager := &serial.SerialPortManager{
-			PortName: c.PortName,
+			PortName: portName,
 			BaudRate: c.BaudRate,
 			Timeout:  c.Timeout,
+			Opener:   c.Opener,
+
+			OnDisconnect: c.handleDisconnect,
 		}
 
-		err := manager.Open()
+		err = manager.Open()
 
 		if err != nil {
 			return err
 		}
 
 		c.serialManager = manager
+		opened = true
+	}
+
+	err := c.optLock.LockContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	// 关闭或设备断开后重新打开
+	reopened := false
+	if c.closed.Load() || !c.serialManager.IsOpen() {
+		// 设备重新插入后端口可能改变，按身份重新解析
+		if IsIdentity(c.PortName) {
+			portName, err := c.resolvePort(ctx)
+			if err != nil {
+				c.optLock.Unlock()
+				return err
+			}
+			c.serialManager.PortName = portName
+			c.serialManager.BaudRate = c.BaudRate
+		}
+
+		err := c.serialManager.Open()
+		if err != nil {
+			c.optLock.Unlock()
+			return err
+		}
+		c.closed.Store(false)
+		reopened = true
+		opened = true
+	}
+
+	// 读取协程退出（如串口出错）后重新启动
+	if c.readerDone != nil {
+		select {
+		case <-c.readerDone:
+			c.readerStop = nil
+			c.readerDone = nil
+		default:
+		}
 	}
+	c.startReader()
+	c.optLock.Unlock()
 
-	if !c.serialManager.IsOpen() {
-		return c.serialManager.Open()
+	if !c.Passive {
+		// 退出主动解析模式
+		_ = c.sendCommand(ctx, exitActiveParse, false)
+
+		if opened {
+			c.syncReturnMode(ctx)
+		}
 	}
 
-	// 退出主动解析模式
-	_ = c.sendCommand("DRAKJHSUYDGBNCJHGJKSHBDN", false)
+	if reopened && c.disconnected.Swap(false) {
+		c.publishConnection(&models.ConnectionEvent{Connected: true, Time: time.Now()})
+	}
 
-	return nil
+	return ctx.Err()
 }
 
 // GetDeviceInfo 获取设备信息（示例实现，实际需根据协议解析串口返回数据）
 func (c *TjcDisplayClient) GetDeviceInfo() (*models.DeviceInfo, error) {
-	err := c.connect()
+	return c.GetDeviceInfoContext(context.Background())
+}
+
+// GetDeviceInfoContext 获取设备信息，context 取消或超时时立即返回
+func (c *TjcDisplayClient) GetDeviceInfoContext(ctx context.Context) (*models.DeviceInfo, error) {
+	err := c.connect(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	// 发送connect
-	result, err := c.sendCommandAndWaitResult("connect", false)
+	result, err := c.sendCommandAndWaitResult(ctx, "connect", false)
 	if err != nil {
 		return nil, err
 	}
@@ -151,12 +258,12 @@ func parseDeviceInfo(data string) (*models.DeviceInfo, error) {
 
 // GetPage 获取当前页面
 func (c *TjcDisplayClient) GetPage() (int, error) {
-	err := c.connect()
+	err := c.connect(context.Background())
 	if err != nil {
 		return 0, err
 	}
 
-	result, err := c.sendCommandAndWaitResult("sendme", false)
+	result, err := c.sendCommandAndWaitResult(context.Background(), "sendme", false)
 	if err != nil {
 		return 0, err
 	}
@@ -172,22 +279,22 @@ func (c *TjcDisplayClient) GetPage() (int, error) {
 
 // JumpPage 跳转到指定页面
 func (c *TjcDisplayClient) JumpPage(page int) error {
-	err := c.connect()
+	err := c.connect(context.Background())
 	if err != nil {
 		return err
 	}
 
-	return c.sendCommand(fmt.Sprintf("page %d", page), false)
+	return c.sendCommand(context.Background(), fmt.Sprintf("page %d", page), false)
 }
 
-// Prints 打印目标的值或者输入内容
+// Prints 打印目标的值或者输入内容，返回设备原样输出的数据（无起始码和结束符）
 func (c *TjcDisplayClient) Prints(target string) (string, error) {
-	err := c.connect()
+	err := c.connect(context.Background())
 	if err != nil {
 		return "", err
 	}
 
-	result, err := c.sendCommandAndWaitResult(fmt.Sprintf("print %s", target), true)
+	result, err := c.sendCommandAndWaitRawResult(context.Background(), fmt.Sprintf("print %s", target), false)
 	if err != nil {
 		return "", err
 	}
@@ -197,286 +304,122 @@ func (c *TjcDisplayClient) Prints(target string) (string, error) {
 
 // ClickUp 模拟弹起目标按钮
 func (c *TjcDisplayClient) ClickUp(target string) error {
-	err := c.connect()
+	err := c.connect(context.Background())
 	if err != nil {
 		return nil
 	}
 
-	return c.sendCommand(fmt.Sprintf("click %s,0", target), false)
+	return c.sendCommand(context.Background(), fmt.Sprintf("click %s,0", target), false)
 }
 
 // ClickDown 模拟按下目标按钮
 func (c *TjcDisplayClient) ClickDown(target string) error {
-	err := c.connect()
+	err := c.connect(context.Background())
 	if err != nil {
 		return err
 	}
 
-	return c.sendCommand(fmt.Sprintf("click %s,1", target), false)
+	return c.sendCommand(context.Background(), fmt.Sprintf("click %s,1", target), false)
 }
 
 // Hide 隐藏指定目标
 func (c *TjcDisplayClient) Hide(target string) error {
-	err := c.connect()
+	err := c.connect(context.Background())
 	if err != nil {
 		return err
 	}
 
-	return c.sendCommand(fmt.Sprintf("vis %s,0", target), false)
+	return c.sendCommand(context.Background(), fmt.Sprintf("vis %s,0", target), false)
 }
 
 // Show 显示指定目标
 func (c *TjcDisplayClient) Show(target string) error {
-	err := c.connect()
+	err := c.connect(context.Background())
 	if err != nil {
 		return err
 	}
 
-	return c.sendCommand(fmt.Sprintf("vis %s,1", target), false)
+	return c.sendCommand(context.Background(), fmt.Sprintf("vis %s,1", target), false)
 }
 
 // ExecuteCommand 执行原始 TJC 命令
 func (c *TjcDisplayClient) ExecuteCommand(cmd string) ([]byte, error) {
-	err := c.connect()
-	if err != nil {
-		return nil, err
-	}
-
-	return c.sendCommandAndWaitRawResult(cmd, false)
+	return c.ExecuteCommandContext(context.Background(), cmd)
 }
 
-// Upgrade 升级面板程序
-func (c *TjcDisplayClient) Upgrade(programPath string, baudRate int, progressCallback models.UpgradeProgressCallback) error {
-	if baudRate == 0 {
-		baudRate = 921600
-	}
-
-	err := c.connect()
+// ExecuteCommandContext 执行原始 TJC 命令，context 取消或超时时立即返回
+func (c *TjcDisplayClient) ExecuteCommandContext(ctx context.Context, cmd string) ([]byte, error) {
+	err := c.connect(ctx)
 	if err != nil {
-		return err
-	}
-
-	f, err := os.Open(programPath)
-	if err != nil {
-		return fmt.Errorf("failed to open program file: %w", err)
-	}
-	defer f.Close()
-
-	fileInfo, err := f.Stat()
-	if err != nil {
-		return fmt.Errorf("failed to get file info: %w", err)
-	}
-
-	fileSize := fileInfo.Size()
-
-	// 发送 whmi-wri 命令（使用当前连接的波特率）
-	cmd := []byte(fmt.Sprintf("whmi-wri %d,%d,0", fileSize, baudRate))
-	cmd = append(cmd, EndSymbol...)
-	err = c.serialManager.Write(cmd)
-	if err != nil {
-		return fmt.Errorf("failed to initiate upgrade: %w", err)
-	}
-
-	// 等待350ms，确保设备已准备好
-	time.Sleep(350 * time.Millisecond)
-
-	// 切换到下载波特率
-	err = c.serialManager.SetBaudRate(baudRate)
-	if err != nil {
-		return fmt.Errorf("failed to set new baud rate: %w", err)
-	}
-
-	// 读取响应，等待设备准备好接收数据（0x05 = ENQ）
-	resp, err := c.serialManager.ReadExactly(1)
-	if err != nil {
-		return fmt.Errorf("failed to read upgrade response: %w", err)
-	}
-
-	if len(resp) != 1 || resp[0] != 0x05 {
-		return fmt.Errorf("unexpected upgrade response: got 0x%02X, expected 0x05", resp[0])
-	}
-
-	// 初始化进度信息
-	var totalSent int64 = 0
-	startTime := time.Now()
-
-	// 初始进度回调
-	if progressCallback != nil {
-		progressCallback(&models.UpgradeProgress{
-			Current:    0,
-			Total:      fileSize,
-			Percentage: 0.0,
-			Speed:      0,
-			Elapsed:    0,
-			Remaining:  0,
-		})
-	}
-
-	buf := make([]byte, 4096)
-	for {
-		n, err := f.Read(buf)
-		if err != nil {
-			if err == io.EOF {
-				break
-			} else {
-				return fmt.Errorf("failed to read program file: %w", err)
-			}
-		}
-
-		if n > 0 {
-			// 写入数据块
-			err = c.serialManager.Write(buf[:n])
-			if err != nil {
-				return fmt.Errorf("failed to write program data at byte %d: %w", totalSent, err)
-			}
-
-			// 更新已发送字节数
-			totalSent += int64(n)
-
-			// 计算进度信息并调用回调
-			if progressCallback != nil {
-				elapsed := time.Since(startTime)
-				percentage := float64(totalSent) / float64(fileSize) * 100
-
-				var speed int64
-				var remaining time.Duration
-				if elapsed.Seconds() > 0 {
-					speed = int64(float64(totalSent) / elapsed.Seconds())
-					if speed > 0 {
-						remainingBytes := fileSize - totalSent
-						remaining = time.Duration(float64(remainingBytes)/float64(speed)) * time.Second
-					}
-				}
-
-				progressCallback(&models.UpgradeProgress{
-					Current:    totalSent,
-					Total:      fileSize,
-					Percentage: percentage,
-					Speed:      speed,
-					Elapsed:    elapsed,
-					Remaining:  remaining,
-				})
-			}
-
-			// 等待设备响应准备好接收下一块数据（0x05 = ENQ）
-			resp, err := c.serialManager.ReadExactly(1)
-			if err != nil {
-				return fmt.Errorf("failed to read upgrade response at byte %d: %w", totalSent, err)
-			}
-
-			if len(resp) != 1 || resp[0] != 0x05 {
-				return fmt.Errorf("unexpected upgrade response: got 0x%02X, expected 0x05", resp[0])
-			}
-		}
+		return nil, err
 	}
 
-	// 完成回调
-	if progressCallback != nil {
-		elapsed := time.Since(startTime)
-		var speed int64
-		if elapsed.Seconds() > 0 {
-			speed = int64(float64(fileSize) / elapsed.Seconds())
-		}
-
-		progressCallback(&models.UpgradeProgress{
-			Current:    fileSize,
-			Total:      fileSize,
-			Percentage: 100.0,
-			Speed:      speed,
-			Elapsed:    elapsed,
-			Remaining:  0,
-		})
+	result, err := c.sendCommandAndWaitRawResult(ctx, cmd, false)
+	if errors.Is(err, errReadTimeout) && !errors.Is(err, ErrSleeping) {
+		// 设备没有返回数据
+		return nil, nil
 	}
 
-	// 重连串口
-	return c.serialManager.SetBaudRate(c.BaudRate)
+	return result, err
 }
 
 // Open 开启串口连接
 func (c *TjcDisplayClient) Open() error {
-	return c.connect()
+	return c.connect(context.Background())
 }
 
-// Close 关闭串口连接
+// Close 关闭串口连接，同时停止自动重连
 func (c *TjcDisplayClient) Close() error {
-	if c.serialManager != nil && c.serialManager.IsOpen() {
-		return c.serialManager.Close()
-	}
-	return nil
-}
+	c.stopReconnect()
 
-func (c *TjcDisplayClient) sendCommand(cmd string, appendReturnEndBytes bool) error {
 	c.optLock.Lock()
 	defer c.optLock.Unlock()
 
-	cmdBytes := append([]byte(cmd), EndSymbol...)
-	if appendReturnEndBytes {
-		cmdBytes = append(append([]byte("printh "), consts.CodeStringData), EndSymbol...)
-	}
-
-	err := c.serialManager.Write(cmdBytes)
-	if err != nil {
-		return err
-	}
-
-	err = c.serialManager.Flush()
-	if err != nil {
-		return err
-	}
-
-	// 读取响应
-	resData, err := c.serialManager.ReadUntil(EndSymbol)
-	if err != nil {
-		return err
-	}
-
-	// 清理多余数据
-	_, _ = c.serialManager.ReadWithTimeout(50 * time.Millisecond)
-
-	// 解析响应
-	resp, err := parseResponse(resData)
-	if err != nil {
-		return fmt.Errorf("parse response failed: %w", err)
+	if c.serialManager == nil || c.closed.Swap(true) {
+		return nil
 	}
 
-	// 检查是否有错误
-	if respErr := resp.toError(); respErr != nil {
-		return respErr
+	// 先关闭串口使阻塞的读取立即返回，再等待读取协程退出（设备断开时串口已经关闭）
+	err := c.serialManager.Close()
+	if c.readerStop != nil {
+		close(c.readerStop)
+		<-c.readerDone
+		c.readerStop = nil
+		c.readerDone = nil
 	}
 
-	return nil
+	return err
 }
 
-func (c *TjcDisplayClient) sendCommandAndWaitResult(cmd string, startSymbol bool) ([]byte, error) {
-	c.optLock.Lock()
-	defer c.optLock.Unlock()
+func (c *TjcDisplayClient) sendCommand(ctx context.Context, cmd string, appendReturnEndBytes bool) error {
+	_, err := c.sendCommandAndWaitResult(ctx, cmd, appendReturnEndBytes)
 
-	cmdBytes := append([]byte(cmd), EndSymbol...)
-	if startSymbol {
-		cmdBytes = append(append([]byte("printh "), consts.CodeStringData), EndSymbol...)
-	}
+	return err
+}
 
-	err := c.serialManager.Write(cmdBytes)
+func (c *TjcDisplayClient) sendCommandAndWaitResult(ctx context.Context, cmd string, startSymbol bool) ([]byte, error) {
+	resp, err := c.sendCommandAndWaitResponse(ctx, cmd, startSymbol)
 	if err != nil {
 		return nil, err
 	}
 
-	err = c.serialManager.Flush()
-	if err != nil {
-		return nil, err
-	}
+	return resp.Data, nil
+}
 
+func (c *TjcDisplayClient) sendCommandAndWaitResponse(ctx context.Context, cmd string, startSymbol bool) (*Response, error) {
 	// 读取响应
-	resData, err := c.serialManager.ReadUntil(EndSymbol)
+	resData, err := c.sendCommandAndWaitRawResult(ctx, cmd, startSymbol)
 	if err != nil {
 		return nil, err
 	}
 
-	// 清理多余数据
-	_, _ = c.serialManager.ReadWithTimeout(50 * time.Millisecond)
+	// 没有返回即执行成功（bkcmd 为 0 或 2）
+	if resData == nil {
+		return &Response{Type: ResponseTypeSuccess, Code: consts.CodeSuccess}, nil
+	}
 
 	// 解析响应
-	resp, err := parseResponse(resData)
+	resp, err := ParseResponse(resData)
 	if err != nil {
 		return nil, fmt.Errorf("parse response failed: %w", err)
 	}
@@ -486,11 +429,19 @@ func (c *TjcDisplayClient) sendCommandAndWaitResult(cmd string, startSymbol bool
 		return nil, respErr
 	}
 
-	return resp.Data, nil
+	return resp, nil
 }
 
-func (c *TjcDisplayClient) sendCommandAndWaitRawResult(cmd string, startSymbol bool) ([]byte, error) {
-	c.optLock.Lock()
+func (c *TjcDisplayClient) sendCommandAndWaitRawResult(ctx context.Context, cmd string, startSymbol bool) ([]byte, error) {
+	err := c.checkAwake(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.optLock.LockContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 	defer c.optLock.Unlock()
 
 	cmdBytes := append([]byte(cmd), EndSymbol...)
@@ -498,7 +449,14 @@ func (c *TjcDisplayClient) sendCommandAndWaitRawResult(cmd string, startSymbol b
 		cmdBytes = append(append([]byte("printh "), consts.CodeStringData), EndSymbol...)
 	}
 
-	err := c.serialManager.Write(cmdBytes)
+	// 清理多余数据
+	c.discardResponses()
+
+	c.pending.Store(true)
+	defer c.pending.Store(false)
+
+	// 取消后设备迟到的返回会因没有等待中的指令而被丢弃
+	err = c.serialManager.WriteContext(ctx, cmdBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -508,19 +466,23 @@ func (c *TjcDisplayClient) sendCommandAndWaitRawResult(cmd string, startSymbol b
 		return nil, err
 	}
 
-	// 读取响应
-	resData, err := c.serialManager.Read()
-	if err != nil {
-		return nil, err
+	c.trackReturnMode(cmd)
+
+	// 读取响应，按 bkcmd 不一定有返回
+	wait, expected := c.replyWait(cmd)
+	result, err := c.waitResponse(ctx, wait)
+	if !expected && errors.Is(err, errReadTimeout) {
+		return nil, c.implicitSuccess(cmd)
 	}
 
-	return resData, nil
+	return result, c.sleepError(cmd, err)
 }
 
-// parseResponse 解析串口屏返回数据
-func parseResponse(data []byte) (*Response, error) {
-	if len(data) < 1 {
-		return nil, fmt.Errorf("Invalid response length: %d", len(data))
+// parseResponse 解析一帧完整的串口屏返回数据（含结束符，0xFE/0xFD 可以只有一个字节）
+func parseResponse(frame []byte) (*Response, error) {
+	data, err := framePayload(frame)
+	if err != nil {
+		return nil, err
 	}
 
 	resp := &Response{
@@ -569,7 +531,7 @@ func parseResponse(data []byte) (*Response, error) {
 	case consts.CodeStringData,
 		consts.CodeNumberData:
 		resp.Type = ResponseTypeData
-		// 提取数据（去掉第一个字节和结束符）
+		// 提取数据（去掉第一个字节）
 		if len(data) > 1 {
 			resp.Data = data[1:]
 		}
@@ -581,3 +543,20 @@ func parseResponse(data []byte) (*Response, error) {
 
 	return resp, nil
 }
+
+// framePayload 校验帧长度和结束符，返回去掉结束符的数据
+func framePayload(frame []byte) ([]byte, error) {
+	if len(frame) == 1 && isBareReply(frame[0]) {
+		return frame, nil
+	}
+
+	if len(frame) <= len(EndSymbol) {
+		return nil, fmt.Errorf("Invalid response length: %d", len(frame))
+	}
+
+	if !bytes.HasSuffix(frame, EndSymbol) {
+		return nil, fmt.Errorf("Invalid response end bytes: % X", frame[len(frame)-len(EndSymbol):])
+	}
+
+	return frame[:len(frame)-len(EndSymbol)], nil
+}
@@ -1,10 +1,19 @@
 package client
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/blue-cloud-net/tjc-serial-display/internal/serial"
+	"github.com/blue-cloud-net/tjc-serial-display/internal/simulator"
 	"github.com/blue-cloud-net/tjc-serial-display/pkg/consts"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/models"
+	goserial "go.bug.st/serial"
 )
 
 // TestParseResponse_Success 测试解析成功响应
@@ -343,12 +352,34 @@ func TestTjcDisplayClient_Connect(t *testing.T) {
 		BaudRate: 921600,
 	}
 
-	err := client.connect()
+	err := client.connect(context.Background())
 	if err == nil {
 		t.Error("Expected error when connecting to non-existent port, got nil")
 	}
 }
 
+// TestTjcDisplayClient_Connect_CustomOpener 测试自定义传输通道时不检查系统串口列表
+func TestTjcDisplayClient_Connect_CustomOpener(t *testing.T) {
+	var opened string
+	client := &TjcDisplayClient{
+		PortName: "fake://screen",
+		BaudRate: 115200,
+		Timeout:  10 * time.Millisecond,
+		Opener: func(portName string, mode *goserial.Mode) (serial.Transport, error) {
+			opened = portName
+			return nil, errors.New("opener called")
+		},
+	}
+
+	err := client.connect(context.Background())
+	if err == nil || err.Error() != "opener called" {
+		t.Fatalf("Expected opener error, got %v", err)
+	}
+	if opened != "fake://screen" {
+		t.Errorf("Expected opener to receive fake://screen, got %s", opened)
+	}
+}
+
 // TestTjcDisplayClient_Close 测试关闭未连接的客户端
 func TestTjcDisplayClient_Close(t *testing.T) {
 	client := &TjcDisplayClient{}
@@ -359,6 +390,106 @@ func TestTjcDisplayClient_Close(t *testing.T) {
 	}
 }
 
+// TestTjcDisplayClient_Reopen 测试设备拔出后读取出错，重新插入后可以关闭并重新打开
+func TestTjcDisplayClient_Reopen(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	client := newSimulatedClient(t, device)
+	if err := client.Open(); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	device.Unplug()
+
+	deadline := time.Now().Add(time.Second)
+	for client.ReadErr() == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected read error after unplug")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	client.Close()
+	if err := client.Open(); err == nil {
+		t.Fatal("Expected open to fail while unplugged")
+	}
+
+	device.Plug()
+	if err := client.Open(); err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	if client.ReadErr() != nil {
+		t.Errorf("Expected no read error after reopen, got %v", client.ReadErr())
+	}
+
+	page, err := client.GetPage()
+	if err != nil || page != 0 {
+		t.Errorf("Expected page 0 after reopen, got %d, %v", page, err)
+	}
+}
+
+// TestTjcDisplayClient_AutoReconnect 测试设备拔出后通知断开，重新插入后自动重连并通知
+func TestTjcDisplayClient_AutoReconnect(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	client := newSimulatedClient(t, device)
+	client.AutoReconnect = true
+	client.MaxReconnectInterval = time.Second
+
+	states := make(chan *models.ConnectionEvent, 4)
+	unsubscribe := client.SubscribeConnection(func(event *models.ConnectionEvent) {
+		states <- event
+	})
+	defer unsubscribe()
+
+	if err := client.Open(); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	waitState := func(connected bool) *models.ConnectionEvent {
+		t.Helper()
+		select {
+		case event := <-states:
+			if event.Connected != connected {
+				t.Fatalf("Expected connected=%v, got %v", connected, event)
+			}
+			return event
+		case <-time.After(3 * time.Second):
+			t.Fatalf("Timeout waiting for connected=%v", connected)
+			return nil
+		}
+	}
+
+	device.Unplug()
+	event := waitState(false)
+	if event.Err == nil {
+		t.Errorf("Expected disconnect reason, got %v", event.Err)
+	}
+	if client.IsConnected() {
+		t.Error("Expected client to report disconnected")
+	}
+	if _, err := client.GetPage(); err == nil {
+		t.Error("Expected command to fail while unplugged")
+	}
+
+	device.Plug()
+	waitState(true)
+	if !client.IsConnected() {
+		t.Error("Expected client to report connected")
+	}
+
+	page, err := client.GetPage()
+	if err != nil || page != 0 {
+		t.Errorf("Expected page 0 after reconnect, got %d, %v", page, err)
+	}
+
+	// 主动关闭不触发通知
+	client.Close()
+	select {
+	case event := <-states:
+		t.Errorf("Unexpected notification after Close: %v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
 // TestTjcDisplayClient_GetDeviceInfo_RealDevice 测试获取真实设备信息
 // 此测试需要连接到真实的串口设备
 func TestTjcDisplayClient_GetDeviceInfo_RealDevice(t *testing.T) {
@@ -419,3 +550,182 @@ func TestTjcDisplayClient_BaseWorkflow(t *testing.T) {
 		t.Logf("Current page: %d", page)
 	}
 }
+
+// newSimulatedClient 创建连接到模拟设备的客户端
+func newSimulatedClient(t *testing.T, device *simulator.Device) *TjcDisplayClient {
+	t.Helper()
+
+	client := &TjcDisplayClient{
+		PortName: "sim",
+		BaudRate: 115200,
+		Timeout:  200 * time.Millisecond,
+		Opener:   device.Opener(),
+	}
+	t.Cleanup(func() { client.Close() })
+
+	return client
+}
+
+// TestTjcDisplayClient_SimulatedWorkflow 使用模拟设备测试基础工作流程
+func TestTjcDisplayClient_SimulatedWorkflow(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	main := device.AddPage("main")
+	device.AddComponent(main, 1, "b0", nil)
+	client := newSimulatedClient(t, device)
+
+	info, err := client.GetDeviceInfo()
+	if err != nil {
+		t.Fatalf("GetDeviceInfo failed: %v", err)
+	}
+	if info.Model != "TJC4024T032_011R" || info.Number != "D264B8204F0E1828" {
+		t.Errorf("Unexpected device info: %+v", info)
+	}
+
+	if err := client.JumpPage(main); err != nil {
+		t.Fatalf("JumpPage failed: %v", err)
+	}
+	page, err := client.GetPage()
+	if err != nil {
+		t.Fatalf("GetPage failed: %v", err)
+	}
+	if page != main {
+		t.Errorf("Expected page %d, got %d", main, page)
+	}
+
+	if err := client.Hide("b0"); err != nil {
+		t.Fatalf("Hide failed: %v", err)
+	}
+	if device.Component(main, "b0").Visible {
+		t.Error("Expected b0 to be hidden")
+	}
+	if err := client.ClickDown("b0"); err != nil {
+		t.Fatalf("ClickDown failed: %v", err)
+	}
+	if !device.Component(main, "b0").Pressed {
+		t.Error("Expected b0 to be pressed")
+	}
+
+	// 无效页面应返回 TJC 错误码
+	err = client.JumpPage(9)
+	tjcErr, ok := err.(*TjcError)
+	if !ok || tjcErr.Code != consts.CodeInvalidPageID {
+		t.Errorf("Expected invalid page error, got %v", err)
+	}
+}
+
+// TestTjcDisplayClient_SimulatedUpgrade 使用模拟设备测试程序升级
+func TestTjcDisplayClient_SimulatedUpgrade(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	client := newSimulatedClient(t, device)
+
+	path, program := writeProgram(t, 10000)
+
+	var last *models.UpgradeProgress
+	err := client.Upgrade(path, 921600, func(progress *models.UpgradeProgress) {
+		last = progress
+	})
+	if err != nil {
+		t.Fatalf("Upgrade failed: %v", err)
+	}
+
+	if !bytes.Equal(device.UpgradeData(), program) {
+		t.Error("Expected device to receive the whole program")
+	}
+	if last == nil || last.Percentage != 100 {
+		t.Errorf("Expected final progress at 100%%, got %+v", last)
+	}
+
+	// 升级结束后客户端恢复原波特率，仍可通信
+	if _, err := client.GetDeviceInfo(); err != nil {
+		t.Errorf("GetDeviceInfo after upgrade failed: %v", err)
+	}
+}
+
+// TestTjcDisplayClient_Context 测试指令等待返回时 context 超时和取消
+func TestTjcDisplayClient_Context(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	client := newSimulatedClient(t, device)
+
+	// 连接并确认设备正常
+	if _, err := client.GetDeviceInfo(); err != nil {
+		t.Fatalf("GetDeviceInfo failed: %v", err)
+	}
+
+	// 设备不返回数据时（bkcmd=0 下查询不存在的控件），context 超时先于指令超时返回
+	if _, err := client.ExecuteCommand("bkcmd=0"); err != nil {
+		t.Fatalf("ExecuteCommand failed: %v", err)
+	}
+	client.Timeout = 5 * time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.ExecuteCommandContext(ctx, "get missing.val")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected command to abort quickly, took %v", elapsed)
+	}
+
+	// 已取消的 context 不再发送指令
+	canceled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	if _, err := client.GetDeviceInfoContext(canceled); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	// 取消后连接仍可继续使用
+	client.Timeout = 200 * time.Millisecond
+	if _, err := client.ExecuteCommand("bkcmd=3"); err != nil {
+		t.Fatalf("ExecuteCommand failed: %v", err)
+	}
+	info, err := client.GetDeviceInfoContext(context.Background())
+	if err != nil {
+		t.Fatalf("GetDeviceInfoContext after cancellation failed: %v", err)
+	}
+	if info.Model != "TJC4024T032_011R" {
+		t.Errorf("Unexpected device info: %+v", info)
+	}
+}
+
+// TestTjcDisplayClient_NetworkPort 测试通过 tcp:// 地址连接串口服务器
+func TestTjcDisplayClient_NetworkPort(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+
+	// 透明串口服务器替身：把 TCP 连接转发到模拟设备
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		port := device.Connect(115200)
+		defer port.Close()
+
+		go io.Copy(conn, port)
+		io.Copy(port, conn)
+	}()
+
+	client := &TjcDisplayClient{
+		PortName: "tcp://" + listener.Addr().String(),
+		BaudRate: 115200,
+		Timeout:  200 * time.Millisecond,
+	}
+	defer client.Close()
+
+	info, err := client.GetDeviceInfo()
+	if err != nil {
+		t.Fatalf("GetDeviceInfo over tcp failed: %v", err)
+	}
+	if info.Model != "TJC4024T032_011R" {
+		t.Errorf("Unexpected device info: %+v", info)
+	}
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/components"
+)
+
+// Execute 执行指令并检查设备返回的错误码
+func (c *TjcDisplayClient) Execute(instruction string) error {
+	err := c.connect(context.Background())
+	if err != nil {
+		return err
+	}
+
+	return c.sendCommand(context.Background(), instruction, false)
+}
+
+// Text 获取文本控件句柄
+func (c *TjcDisplayClient) Text(name string) *components.Text {
+	return components.NewText(c, name)
+}
+
+// Number 获取数字控件句柄
+func (c *TjcDisplayClient) Number(name string) *components.Number {
+	return components.NewNumber(c, name)
+}
+
+// Button 获取按钮控件句柄
+func (c *TjcDisplayClient) Button(name string) *components.Button {
+	return components.NewButton(c, name)
+}
+
+// Slider 获取滑块控件句柄
+func (c *TjcDisplayClient) Slider(name string) *components.Slider {
+	return components.NewSlider(c, name)
+}
+
+// Picture 获取图片控件句柄
+func (c *TjcDisplayClient) Picture(name string) *components.Picture {
+	return components.NewPicture(c, name)
+}
+
+// ProgressBar 获取进度条控件句柄
+func (c *TjcDisplayClient) ProgressBar(name string) *components.ProgressBar {
+	return components.NewProgressBar(c, name)
+}
+
+// Waveform 获取曲线控件句柄
+func (c *TjcDisplayClient) Waveform(name string) *components.Waveform {
+	return components.NewWaveform(c, name)
+}
@@ -0,0 +1,299 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/internal/simulator"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/components"
+)
+
+// TestComponents_Escape 测试字符串转义
+func TestComponents_Escape(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"hello", "hello"},
+		{`say "hi"`, `say \"hi\"`},
+		{`C:\tjc`, `C:\\tjc`},
+		{"line1\nline2", `line1\rline2`},
+		{"line1\r\nline2", `line1\rline2`},
+	}
+
+	for _, tc := range testCases {
+		if got := components.Escape(tc.input); got != tc.expected {
+			t.Errorf("Escape(%q): expected %q, got %q", tc.input, tc.expected, got)
+		}
+	}
+}
+
+// TestComponents_ValidateName 测试控件名称校验
+func TestComponents_ValidateName(t *testing.T) {
+	for _, name := range []string{"t0", "page1.t0", "b_ok"} {
+		if err := components.ValidateName(name); err != nil {
+			t.Errorf("ValidateName(%q): expected valid, got %v", name, err)
+		}
+	}
+
+	for _, name := range []string{"", "t 0", `t0"`, "t0,1", "t0=1", "t0\r", "t0\xFF"} {
+		if err := components.ValidateName(name); !errors.Is(err, components.ErrInvalidName) {
+			t.Errorf("ValidateName(%q): expected ErrInvalidName, got %v", name, err)
+		}
+	}
+}
+
+// TestComponents_Simulated 使用模拟设备测试类型化控件句柄
+func TestComponents_Simulated(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	device.AddComponent(0, 1, "t0", map[string]any{"txt": ""})
+	device.AddComponent(0, 2, "n0", map[string]any{"val": int32(0)})
+	device.AddComponent(0, 3, "b0", map[string]any{"txt": "OK"})
+	device.AddComponent(0, 4, "h0", map[string]any{"val": int32(0), "minval": int32(0), "maxval": int32(100)})
+	device.AddComponent(0, 5, "p0", map[string]any{"pic": int32(0)})
+	device.AddComponent(0, 6, "j0", map[string]any{"val": int32(0)})
+	client := newSimulatedClient(t, device)
+
+	text := client.Text("page0.t0")
+	if err := text.Set(`say "hi"`); err != nil {
+		t.Fatalf("Text.Set failed: %v", err)
+	}
+	if got, err := text.Get(); err != nil || got != `say "hi"` {
+		t.Errorf("Text.Get: expected %q, got %q, %v", `say "hi"`, got, err)
+	}
+
+	number := client.Number("n0")
+	if err := number.Set(-42); err != nil {
+		t.Fatalf("Number.Set failed: %v", err)
+	}
+	if got, err := number.Get(); err != nil || got != -42 {
+		t.Errorf("Number.Get: expected -42, got %d, %v", got, err)
+	}
+
+	button := client.Button("b0")
+	if err := button.Press(); err != nil {
+		t.Fatalf("Button.Press failed: %v", err)
+	}
+	if !device.Component(0, "b0").Pressed {
+		t.Error("Expected b0 to be pressed")
+	}
+	if err := button.Click(); err != nil {
+		t.Fatalf("Button.Click failed: %v", err)
+	}
+	if device.Component(0, "b0").Pressed {
+		t.Error("Expected b0 to be released after click")
+	}
+	if err := button.Hide(); err != nil {
+		t.Fatalf("Button.Hide failed: %v", err)
+	}
+	if device.Component(0, "b0").Visible {
+		t.Error("Expected b0 to be hidden")
+	}
+
+	slider := client.Slider("h0")
+	if err := slider.SetRange(10, 20); err != nil {
+		t.Fatalf("Slider.SetRange failed: %v", err)
+	}
+	if err := slider.Set(15); err != nil {
+		t.Fatalf("Slider.Set failed: %v", err)
+	}
+	if got := device.Component(0, "h0").Attrs["maxval"]; got != int32(20) {
+		t.Errorf("Expected maxval 20, got %v", got)
+	}
+	if err := slider.Set(30); !errors.Is(err, components.ErrOutOfRange) {
+		t.Errorf("Expected ErrOutOfRange, got %v", err)
+	}
+	if err := slider.SetRange(50, 10); !errors.Is(err, components.ErrOutOfRange) {
+		t.Errorf("Expected ErrOutOfRange for inverted range, got %v", err)
+	}
+
+	picture := client.Picture("p0")
+	if err := picture.SetPic(3); err != nil {
+		t.Fatalf("Picture.SetPic failed: %v", err)
+	}
+	if got, err := picture.GetPic(); err != nil || got != 3 {
+		t.Errorf("Picture.GetPic: expected 3, got %d, %v", got, err)
+	}
+	if err := picture.SetPic(-1); !errors.Is(err, components.ErrOutOfRange) {
+		t.Errorf("Expected ErrOutOfRange, got %v", err)
+	}
+
+	progress := client.ProgressBar("j0")
+	if err := progress.SetVal(75); err != nil {
+		t.Fatalf("ProgressBar.SetVal failed: %v", err)
+	}
+	if err := progress.SetVal(101); !errors.Is(err, components.ErrOutOfRange) {
+		t.Errorf("Expected ErrOutOfRange, got %v", err)
+	}
+}
+
+// TestComponents_TypedErrors 测试设备错误码映射为控件错误
+func TestComponents_TypedErrors(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	device.AddComponent(0, 1, "t0", map[string]any{"txt": ""})
+	client := newSimulatedClient(t, device)
+
+	err := client.Text("t9").Set("x")
+	if !errors.Is(err, components.ErrInvalidComponent) {
+		t.Errorf("Expected ErrInvalidComponent, got %v", err)
+	}
+	var tjcErr *TjcError
+	if !errors.As(err, &tjcErr) {
+		t.Errorf("Expected wrapped TjcError, got %v", err)
+	}
+
+	if _, err := client.Number("t9").Get(); !errors.Is(err, components.ErrInvalidAttribute) {
+		t.Errorf("Expected ErrInvalidAttribute, got %v", err)
+	}
+
+	if err := client.Number("t0").SetNumber("txt", 1); !errors.Is(err, components.ErrAssignmentFailed) {
+		t.Errorf("Expected ErrAssignmentFailed, got %v", err)
+	}
+
+	if err := client.Text(`t0"`).Set("x"); !errors.Is(err, components.ErrInvalidName) {
+		t.Errorf("Expected ErrInvalidName, got %v", err)
+	}
+
+	// vis、click 只作用于当前页面，带页面前缀时不能误操作当前页面的同名控件
+	if err := client.Text("page1.t0").Hide(); !errors.Is(err, components.ErrPageQualified) {
+		t.Errorf("Expected ErrPageQualified, got %v", err)
+	}
+	if err := client.Button("page1.t0").Press(); !errors.Is(err, components.ErrPageQualified) {
+		t.Errorf("Expected ErrPageQualified, got %v", err)
+	}
+	if !device.Component(0, "t0").Visible {
+		t.Error("Expected t0 on the current page to stay visible")
+	}
+}
+
+// TestComponents_Waveform 测试曲线控件的 add、addt、cle 以及通道号无效时的错误
+func TestComponents_Waveform(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	device.AddWaveform(0, 7, "s0", 2)
+	client := newSimulatedClient(t, device)
+
+	wave := client.Waveform("s0")
+	if err := wave.Add(0, 10); err != nil {
+		t.Fatalf("Waveform.Add failed: %v", err)
+	}
+	if err := wave.Append(0, []byte{20, 30}); err != nil {
+		t.Fatalf("Waveform.Append failed: %v", err)
+	}
+	if err := wave.Append(1, []byte{40}); err != nil {
+		t.Fatalf("Waveform.Append failed: %v", err)
+	}
+	if got := device.Waveform(0, "s0", 0); !bytes.Equal(got, []byte{10, 20, 30}) {
+		t.Errorf("Expected channel 0 to be 0A 14 1E, got % X", got)
+	}
+
+	if err := wave.Clear(0); err != nil {
+		t.Fatalf("Waveform.Clear failed: %v", err)
+	}
+	if len(device.Waveform(0, "s0", 0)) != 0 || len(device.Waveform(0, "s0", 1)) != 1 {
+		t.Error("Expected only channel 0 to be cleared")
+	}
+	if err := wave.ClearAll(); err != nil {
+		t.Fatalf("Waveform.ClearAll failed: %v", err)
+	}
+	if len(device.Waveform(0, "s0", 1)) != 0 {
+		t.Error("Expected all channels to be cleared")
+	}
+
+	// 控件ID只读取一次
+	var idQueries int
+	for _, cmd := range device.History() {
+		if cmd == "get s0.id" {
+			idQueries++
+		}
+	}
+	if idQueries != 1 {
+		t.Errorf("Expected 1 id query, got %d", idQueries)
+	}
+
+	// 控件只有两个通道
+	if err := wave.Add(2, 1); !errors.Is(err, components.ErrInvalidCurve) {
+		t.Errorf("Expected ErrInvalidCurve, got %v", err)
+	}
+	if err := wave.Append(3, []byte{1, 2}); !errors.Is(err, components.ErrInvalidCurve) {
+		t.Errorf("Expected ErrInvalidCurve from addt, got %v", err)
+	}
+	if err := wave.Add(4, 1); !errors.Is(err, components.ErrOutOfRange) {
+		t.Errorf("Expected ErrOutOfRange for channel 4, got %v", err)
+	}
+	if err := wave.Add(0, 256); !errors.Is(err, components.ErrOutOfRange) {
+		t.Errorf("Expected ErrOutOfRange for 256, got %v", err)
+	}
+}
+
+// TestComponents_WaveformStream 测试数据流按间隔批量发送，关闭时发送剩余数据
+func TestComponents_WaveformStream(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	device.AddWaveform(0, 7, "s0", 1)
+	client := newSimulatedClient(t, device)
+
+	stream := client.Waveform("s0").Stream(0, 20*time.Millisecond)
+	scale := components.Scale{Min: -1, Max: 1}
+	for i := 0; i < 50; i++ {
+		stream.PushScaled(scale, -1, 0, 1)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for len(device.Waveform(0, "s0", 0)) < 150 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	stream.Push(5)
+	if err := stream.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	got := device.Waveform(0, "s0", 0)
+	if len(got) != 151 || !bytes.Equal(got[:3], []byte{0, 128, 255}) || got[150] != 5 {
+		t.Errorf("Unexpected stream data (%d points) % X", len(got), got[:min(len(got), 6)])
+	}
+
+	// 批量发送使用 addt，而不是逐个 add
+	var adds int
+	for _, cmd := range device.History() {
+		if strings.HasPrefix(cmd, "add ") {
+			adds++
+		}
+	}
+	if adds > 1 {
+		t.Errorf("Expected batched addt, got %d add instructions", adds)
+	}
+	if stream.Err() != nil || stream.Dropped() != 0 {
+		t.Errorf("Unexpected stream error %v, dropped %d", stream.Err(), stream.Dropped())
+	}
+}
+
+// TestComponents_WaveformScale 测试数值到曲线像素高度的映射
+func TestComponents_WaveformScale(t *testing.T) {
+	scale := components.Scale{Min: 0, Max: 100}
+	testCases := []struct {
+		value    float64
+		expected byte
+	}{
+		{0, 0},
+		{50, 128},
+		{100, 255},
+		{-10, 0},
+		{150, 255},
+	}
+
+	for _, tc := range testCases {
+		if got := scale.Point(tc.value); got != tc.expected {
+			t.Errorf("Point(%v): expected %d, got %d", tc.value, tc.expected, got)
+		}
+	}
+
+	if got := scale.Value(255); got != 100 {
+		t.Errorf("Value(255): expected 100, got %v", got)
+	}
+	if got := (components.Scale{Min: 1, Max: 1}).Point(1); got != 0 {
+		t.Errorf("Expected empty range to map to 0, got %d", got)
+	}
+}
@@ -0,0 +1,205 @@
+package client
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/internal/serial"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/models"
+	goserial "go.bug.st/serial"
+)
+
+// 自动检测的默认参数
+const (
+	defaultDetectTimeout     = 100 * time.Millisecond
+	defaultDetectConcurrency = 8
+)
+
+// LikelyBaudRates 自动检测时尝试波特率的顺序：出厂默认的 9600 和常用的 115200 优先，其余从高到低
+var LikelyBaudRates = []int{115200, 9600, 921600, 512000, 256000, 230400, 57600, 38400, 19200, 4800, 2400}
+
+// 常见的 USB 转串口芯片（VID:PID），优先探测
+var knownAdapters = []string{
+	"1a86:7523", // CH340
+	"1a86:55d4", // CH9102
+	"10c4:ea60", // CP210x
+	"0403:6001", // FT232R
+	"0403:6015", // FT231X
+	"067b:2303", // PL2303
+}
+
+// DetectedDevice 自动检测到的设备
+type DetectedDevice struct {
+	PortName string              // 串口路径
+	BaudRate int                 // 设备当前的波特率
+	Port     *serial.PortDetails // 串口信息，USB 转串口包含 VID、PID 和 USB 序列号
+	Info     *models.DeviceInfo  // connect 返回的设备信息
+}
+
+// Detector 并发探测所有串口上的 TJC 设备
+//
+// 同一串口只能依次尝试各波特率，不同串口同时探测；USB 转串口（尤其是常见的转换芯片）优先，
+// 在一个串口上找到设备后不再尝试其他波特率
+type Detector struct {
+	Timeout     time.Duration // 探测单个串口和波特率的超时，默认 100ms
+	BaudRates   []int         // 尝试的波特率及顺序，默认 LikelyBaudRates
+	Concurrency int           // 同时探测的串口数，默认 8
+	USBOnly     bool          // 只探测 USB 转串口，跳过板载串口（如 /dev/ttyS*）
+
+	// 以下用于测试，为空时使用系统串口
+	Opener    serial.Opener                         // 探测时打开端口的方式
+	ListPorts func() ([]*serial.PortDetails, error) // 枚举串口，默认 serial.ListPortDetails
+}
+
+// Detect 探测所有串口，返回找到的设备（按串口路径排序），没有找到设备时返回空列表
+func (d *Detector) Detect(ctx context.Context) ([]*DetectedDevice, error) {
+	listPorts := d.ListPorts
+	if listPorts == nil {
+		listPorts = serial.ListPortDetails
+	}
+
+	ports, err := listPorts()
+	if err != nil {
+		return nil, err
+	}
+
+	if d.USBOnly {
+		ports = slices.DeleteFunc(slices.Clone(ports), func(port *serial.PortDetails) bool { return !port.IsUSB })
+	}
+	slices.SortStableFunc(ports, func(a, b *serial.PortDetails) int {
+		return cmp.Compare(portPriority(a), portPriority(b))
+	})
+
+	concurrency := d.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultDetectConcurrency
+	}
+
+	var (
+		mu      sync.Mutex
+		devices []*DetectedDevice
+		wg      sync.WaitGroup
+	)
+	sem := make(chan struct{}, concurrency)
+
+	for _, port := range ports {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			device := d.detectPort(ctx, port)
+			if device != nil {
+				mu.Lock()
+				devices = append(devices, device)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(devices, func(a, b *DetectedDevice) int {
+		return strings.Compare(a.PortName, b.PortName)
+	})
+
+	return devices, nil
+}
+
+// detectPort 依次以各波特率探测一个串口
+func (d *Detector) detectPort(ctx context.Context, port *serial.PortDetails) *DetectedDevice {
+	baudRates := d.BaudRates
+	if len(baudRates) == 0 {
+		baudRates = LikelyBaudRates
+	}
+
+	timeout := d.Timeout
+	if timeout <= 0 {
+		timeout = defaultDetectTimeout
+	}
+
+	for _, baudRate := range baudRates {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		info, err := probeDevice(ctx, d.Opener, port.Name, baudRate, timeout)
+		if err == nil {
+			return &DetectedDevice{PortName: port.Name, BaudRate: baudRate, Port: port, Info: info}
+		}
+	}
+
+	return nil
+}
+
+// portPriority 串口的探测优先级，越小越先探测
+func portPriority(port *serial.PortDetails) int {
+	switch {
+	case port.IsUSB && slices.Contains(knownAdapters, strings.ToLower(port.VID+":"+port.PID)):
+		return 0
+	case port.IsUSB:
+		return 1
+	}
+
+	return 2
+}
+
+// probeDevice 以指定波特率打开端口，只发送 connect 并解析设备信息
+//
+// 不创建完整的客户端，避免向未知设备写入 connect 以外的指令（如 bkcmd），整个探测不超过 timeout
+func probeDevice(ctx context.Context, opener serial.Opener, portName string, baudRate int, timeout time.Duration) (*models.DeviceInfo, error) {
+	if opener == nil {
+		opener = serial.OpenPort
+	}
+
+	port, err := opener(portName, &goserial.Mode{BaudRate: baudRate})
+	if err != nil {
+		return nil, err
+	}
+	defer port.Close()
+
+	if _, err := port.Write([]byte("connect" + EndStr)); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	_ = port.SetReadTimeout(min(timeout, readerPollInterval))
+
+	var decoder FrameDecoder
+	buf := make([]byte, 256)
+	for ctx.Err() == nil {
+		n, err := port.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+		decoder.Write(buf[:n])
+
+		for frame := decoder.Next(); frame != nil; frame = decoder.Next() {
+			reply := bytes.TrimSuffix(frame, EndSymbol)
+			if bytes.HasPrefix(reply, []byte("comok")) {
+				return parseDeviceInfo(string(reply))
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("no reply to connect on %s at %d baud: %w", portName, baudRate, errReadTimeout)
+}
@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/internal/serial"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/consts"
+)
+
+// TestLikelyBaudRates 测试检测顺序覆盖所有支持的波特率
+func TestLikelyBaudRates(t *testing.T) {
+	likely := slices.Sorted(slices.Values(LikelyBaudRates))
+	supported := slices.Sorted(slices.Values(consts.SupportedBaudrate))
+	if !slices.Equal(likely, supported) {
+		t.Errorf("LikelyBaudRates %v does not match SupportedBaudrate %v", LikelyBaudRates, consts.SupportedBaudrate)
+	}
+}
+
+// TestDetector_Detect 测试并发探测返回所有设备及其波特率和 USB 信息
+func TestDetector_Detect(t *testing.T) {
+	ports := newTestPorts()
+	ports.attach("/dev/ttyUSB1", newNumberedDevice("AAAA000000000001", 9600), &serial.PortDetails{IsUSB: true, VID: "1A86", PID: "7523"})
+	ports.attach("/dev/ttyUSB0", newNumberedDevice("BBBB000000000002", 921600), &serial.PortDetails{IsUSB: true, VID: "10C4", PID: "EA60", SerialNumber: "0001"})
+	ports.attach("/dev/ttyS0", newNumberedDevice("CCCC000000000003", 2400), nil)
+	ports.attach("/dev/ttyS1", nil, nil) // 没有设备
+
+	detector := &Detector{
+		Timeout:   50 * time.Millisecond,
+		Opener:    ports.opener(),
+		ListPorts: ports.list,
+	}
+
+	devices, err := detector.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+
+	expected := []struct {
+		port   string
+		baud   int
+		number string
+	}{
+		{"/dev/ttyS0", 2400, "CCCC000000000003"},
+		{"/dev/ttyUSB0", 921600, "BBBB000000000002"},
+		{"/dev/ttyUSB1", 9600, "AAAA000000000001"},
+	}
+	if len(devices) != len(expected) {
+		t.Fatalf("Expected %d devices, got %d", len(expected), len(devices))
+	}
+	for i, e := range expected {
+		device := devices[i]
+		if device.PortName != e.port || device.BaudRate != e.baud || device.Info.Number != e.number {
+			t.Errorf("Device %d: expected %s@%d %s, got %s@%d %s", i, e.port, e.baud, e.number, device.PortName, device.BaudRate, device.Info.Number)
+		}
+	}
+	if devices[1].Port.SerialNumber != "0001" {
+		t.Errorf("Expected USB details, got %+v", devices[1].Port)
+	}
+
+	// 只探测 USB 转串口
+	detector.USBOnly = true
+	devices, err = detector.Detect(context.Background())
+	if err != nil || len(devices) != 2 {
+		t.Errorf("Expected 2 USB devices, got %d, %v", len(devices), err)
+	}
+}
+
+// TestDetector_Context 测试取消探测
+func TestDetector_Context(t *testing.T) {
+	ports := newTestPorts()
+	ports.attach("/dev/ttyUSB0", newNumberedDevice("AAAA000000000001", 2400), nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	detector := &Detector{Timeout: 50 * time.Millisecond, Opener: ports.opener(), ListPorts: ports.list}
+	if _, err := detector.Detect(ctx); err == nil {
+		t.Error("Expected context error")
+	}
+}
+
+// TestPortPriority 测试常见 USB 转串口芯片优先探测
+func TestPortPriority(t *testing.T) {
+	ch340 := &serial.PortDetails{IsUSB: true, VID: "1A86", PID: "7523"}
+	other := &serial.PortDetails{IsUSB: true, VID: "2341", PID: "0043"}
+	onboard := &serial.PortDetails{}
+
+	if !(portPriority(ch340) < portPriority(other) && portPriority(other) < portPriority(onboard)) {
+		t.Errorf("Unexpected priorities: %d, %d, %d", portPriority(ch340), portPriority(other), portPriority(onboard))
+	}
+}
+
+// TestProbeDevice 测试探测只发送 connect，波特率不匹配时在超时内返回
+func TestProbeDevice(t *testing.T) {
+	device := newNumberedDevice("AAAA000000000001", 9600)
+
+	info, err := probeDevice(context.Background(), device.Opener(), "sim", 9600, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("probeDevice failed: %v", err)
+	}
+	if info.Number != "AAAA000000000001" {
+		t.Errorf("Expected number AAAA000000000001, got %s", info.Number)
+	}
+	if history := device.History(); !slices.Equal(history, []string{"connect"}) {
+		t.Errorf("Expected only connect to be sent, got %q", history)
+	}
+
+	start := time.Now()
+	if _, err := probeDevice(context.Background(), device.Opener(), "sim", 115200, 50*time.Millisecond); err == nil {
+		t.Error("Expected error for mismatched baud rate")
+	}
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Errorf("Expected probe to give up within the timeout, took %v", elapsed)
+	}
+}
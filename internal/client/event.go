@@ -0,0 +1,172 @@
+package client
+
+import (
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/consts"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/models"
+)
+
+// isUnsolicited 判断响应是否为设备主动上报、不会作为指令返回的事件
+func isUnsolicited(code byte) bool {
+	switch code {
+	case consts.CodeTouchEvent,
+		consts.CodeTouchCoordinate,
+		consts.CodeSleepTouch,
+		consts.CodeAutoSleep,
+		consts.CodeAutoWake,
+		consts.CodeStartupSuccess,
+		consts.CodeStartSDUpgrade:
+		return true
+	}
+
+	return false
+}
+
+// parseEvent 将事件响应解析为类型化事件
+func parseEvent(resp *Response) (*models.Event, bool) {
+	event := &models.Event{
+		Code: resp.Code,
+		Raw:  resp.RawData,
+		Time: time.Now(),
+	}
+	data := resp.Data
+
+	switch resp.Code {
+	case consts.CodeTouchEvent:
+		// 0x65 页面ID 控件ID 状态(0x01按下/0x00弹起)
+		if len(data) != 3 {
+			return nil, false
+		}
+		event.Type = models.EventTouch
+		event.Page = int(data[0])
+		event.Component = int(data[1])
+		event.Pressed = data[2] == 0x01
+	case consts.CodePageID:
+		// 0x66 页面ID
+		if len(data) != 1 {
+			return nil, false
+		}
+		event.Type = models.EventPage
+		event.Page = int(data[0])
+	case consts.CodeTouchCoordinate, consts.CodeSleepTouch:
+		// 0x67/0x68 x坐标高位 x坐标低位 y坐标高位 y坐标低位 状态
+		if len(data) != 5 {
+			return nil, false
+		}
+		event.Type = models.EventTouchCoordinate
+		if resp.Code == consts.CodeSleepTouch {
+			event.Type = models.EventSleepTouch
+		}
+		event.X = int(data[0])<<8 | int(data[1])
+		event.Y = int(data[2])<<8 | int(data[3])
+		event.Pressed = data[4] == 0x01
+	case consts.CodeAutoSleep:
+		event.Type = models.EventAutoSleep
+	case consts.CodeAutoWake:
+		event.Type = models.EventAutoWake
+	case consts.CodeStartupSuccess:
+		event.Type = models.EventStartup
+	case consts.CodeStartSDUpgrade:
+		event.Type = models.EventSDUpgrade
+	default:
+		return nil, false
+	}
+
+	return event, true
+}
+
+// Event 将事件响应解析为类型化事件，非事件或数据不完整时返回 false
+func (r *Response) Event() (*models.Event, bool) {
+	if r.Type != ResponseTypeEvent {
+		return nil, false
+	}
+
+	return parseEvent(r)
+}
+
+// Subscribe 订阅设备事件，返回取消订阅函数
+// 回调在后台读取协程中执行，不应长时间阻塞
+func (c *TjcDisplayClient) Subscribe(callback models.EventCallback) func() {
+	c.subLock.Lock()
+	defer c.subLock.Unlock()
+
+	if c.subscribers == nil {
+		c.subscribers = make(map[int]models.EventCallback)
+	}
+
+	id := c.nextSubID
+	c.nextSubID++
+	c.subscribers[id] = callback
+
+	return func() {
+		c.subLock.Lock()
+		defer c.subLock.Unlock()
+
+		delete(c.subscribers, id)
+	}
+}
+
+// Events 以通道形式订阅设备事件，通道已满时丢弃新事件，返回取消订阅函数
+func (c *TjcDisplayClient) Events(size int) (<-chan *models.Event, func()) {
+	ch := make(chan *models.Event, size)
+
+	unsubscribe := c.Subscribe(func(event *models.Event) {
+		select {
+		case ch <- event:
+		default:
+		}
+	})
+
+	return ch, unsubscribe
+}
+
+// SubscribeFrames 订阅串口收到的每一帧原始数据（事件、指令返回以及没有结束符的 print 输出），
+// 返回取消订阅函数。回调在后台读取协程中执行，frame 不可修改
+func (c *TjcDisplayClient) SubscribeFrames(callback func(frame []byte)) func() {
+	c.subLock.Lock()
+	defer c.subLock.Unlock()
+
+	if c.frameSubscribers == nil {
+		c.frameSubscribers = make(map[int]func(frame []byte))
+	}
+
+	id := c.nextSubID
+	c.nextSubID++
+	c.frameSubscribers[id] = callback
+
+	return func() {
+		c.subLock.Lock()
+		defer c.subLock.Unlock()
+
+		delete(c.frameSubscribers, id)
+	}
+}
+
+// publishFrame 将原始数据帧分发给所有帧订阅者
+func (c *TjcDisplayClient) publishFrame(frame []byte) {
+	c.subLock.Lock()
+	callbacks := make([]func(frame []byte), 0, len(c.frameSubscribers))
+	for _, callback := range c.frameSubscribers {
+		callbacks = append(callbacks, callback)
+	}
+	c.subLock.Unlock()
+
+	for _, callback := range callbacks {
+		callback(frame)
+	}
+}
+
+// publish 将事件分发给所有订阅者
+func (c *TjcDisplayClient) publish(event *models.Event) {
+	c.subLock.Lock()
+	callbacks := make([]models.EventCallback, 0, len(c.subscribers))
+	for _, callback := range c.subscribers {
+		callbacks = append(callbacks, callback)
+	}
+	c.subLock.Unlock()
+
+	for _, callback := range callbacks {
+		callback(event)
+	}
+}
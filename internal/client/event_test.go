@@ -0,0 +1,227 @@
+package client
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/internal/simulator"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/consts"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/models"
+)
+
+// TestParseEvent 测试事件解析
+func TestParseEvent(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     []byte
+		expected models.Event
+	}{
+		{"TouchPress", []byte{0x65, 0x01, 0x02, 0x01, 0xFF, 0xFF, 0xFF}, models.Event{Type: models.EventTouch, Page: 1, Component: 2, Pressed: true}},
+		{"TouchRelease", []byte{0x65, 0x00, 0x05, 0x00, 0xFF, 0xFF, 0xFF}, models.Event{Type: models.EventTouch, Component: 5}},
+		{"Page", []byte{0x66, 0x03, 0xFF, 0xFF, 0xFF}, models.Event{Type: models.EventPage, Page: 3}},
+		{"Coordinate", []byte{0x67, 0x01, 0x2C, 0x00, 0xF0, 0x01, 0xFF, 0xFF, 0xFF}, models.Event{Type: models.EventTouchCoordinate, X: 300, Y: 240, Pressed: true}},
+		{"SleepTouch", []byte{0x68, 0x00, 0x10, 0x00, 0x20, 0x00, 0xFF, 0xFF, 0xFF}, models.Event{Type: models.EventSleepTouch, X: 16, Y: 32}},
+		{"AutoSleep", []byte{0x86, 0xFF, 0xFF, 0xFF}, models.Event{Type: models.EventAutoSleep}},
+		{"AutoWake", []byte{0x87, 0xFF, 0xFF, 0xFF}, models.Event{Type: models.EventAutoWake}},
+		{"Startup", []byte{0x88, 0xFF, 0xFF, 0xFF}, models.Event{Type: models.EventStartup}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := parseResponse(tc.data)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			event, ok := parseEvent(resp)
+			if !ok {
+				t.Fatal("Expected event to be parsed")
+			}
+			if event.Type != tc.expected.Type || event.Page != tc.expected.Page ||
+				event.Component != tc.expected.Component || event.Pressed != tc.expected.Pressed ||
+				event.X != tc.expected.X || event.Y != tc.expected.Y {
+				t.Errorf("Expected %+v, got %+v", tc.expected, *event)
+			}
+		})
+	}
+}
+
+// TestParseEvent_InvalidPayload 测试长度不正确的事件
+func TestParseEvent_InvalidPayload(t *testing.T) {
+	for _, data := range [][]byte{{0x65, 0x01, 0xFF, 0xFF, 0xFF}, {0x67, 0x00, 0x01, 0xFF, 0xFF, 0xFF}, {0x66, 0xFF, 0xFF, 0xFF}} {
+		resp, _ := parseResponse(data)
+		if _, ok := parseEvent(resp); ok {
+			t.Errorf("Expected % X to be rejected", data)
+		}
+	}
+}
+
+// waitEvent 从事件通道中等待一个事件
+func waitEvent(t *testing.T, events <-chan *models.Event) *models.Event {
+	t.Helper()
+
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for event")
+		return nil
+	}
+}
+
+// TestTjcDisplayClient_Events 测试后台读取协程分发主动上报的事件
+func TestTjcDisplayClient_Events(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	device.AddComponent(0, 4, "b0", nil).SendTouch = true
+	client := newSimulatedClient(t, device)
+
+	if err := client.Open(); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	events, unsubscribe := client.Events(8)
+	defer unsubscribe()
+
+	device.Touch(0, 4, true)
+	event := waitEvent(t, events)
+	if event.Type != models.EventTouch || event.Component != 4 || !event.Pressed {
+		t.Errorf("Unexpected touch event: %+v", event)
+	}
+
+	device.Emit(consts.CodeAutoSleep)
+	if event := waitEvent(t, events); event.Type != models.EventAutoSleep {
+		t.Errorf("Expected auto sleep event, got %+v", event)
+	}
+
+	// 指令执行过程中产生的事件不应被误认为指令返回
+	if err := client.ClickDown("b0"); err != nil {
+		t.Fatalf("ClickDown failed: %v", err)
+	}
+	if event := waitEvent(t, events); event.Type != models.EventTouch || event.Component != 4 {
+		t.Errorf("Expected touch event from click, got %+v", event)
+	}
+
+	device.Emit(consts.CodeStartupSuccess)
+	page, err := client.GetPage()
+	if err != nil {
+		t.Fatalf("GetPage failed: %v", err)
+	}
+	if page != 0 {
+		t.Errorf("Expected page 0, got %d", page)
+	}
+	if event := waitEvent(t, events); event.Type != models.EventStartup {
+		t.Errorf("Expected startup event, got %+v", event)
+	}
+
+	// 取消订阅后不再收到事件
+	unsubscribe()
+	device.Emit(consts.CodeAutoWake)
+	select {
+	case event := <-events:
+		t.Errorf("Expected no event after unsubscribe, got %+v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// TestTjcDisplayClient_Subscribe 测试回调方式订阅事件
+func TestTjcDisplayClient_Subscribe(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	client := newSimulatedClient(t, device)
+
+	received := make(chan *models.Event, 1)
+	unsubscribe := client.Subscribe(func(event *models.Event) {
+		received <- event
+	})
+	defer unsubscribe()
+
+	if err := client.Open(); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	device.Emit(consts.CodeTouchCoordinate, 0x00, 0x64, 0x00, 0xC8, 0x01)
+	event := waitEvent(t, received)
+	if event.Type != models.EventTouchCoordinate || event.X != 100 || event.Y != 200 {
+		t.Errorf("Unexpected coordinate event: %+v", event)
+	}
+}
+
+// TestTjcDisplayClient_SubscribeFrames 测试订阅全部原始数据帧
+func TestTjcDisplayClient_SubscribeFrames(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	device.AddComponent(0, 1, "t0", map[string]any{"txt": "hi"})
+	client := newSimulatedClient(t, device)
+
+	if err := client.Open(); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	frames := make(chan []byte, 16)
+	unsubscribe := client.SubscribeFrames(func(frame []byte) {
+		frames <- frame
+	})
+	defer unsubscribe()
+
+	// 每次指令前退出主动解析模式的返回也会被收到，跳过不关心的帧
+	expectFrame := func(want []byte) {
+		t.Helper()
+		for {
+			select {
+			case frame := <-frames:
+				if bytes.Equal(frame, want) {
+					return
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("Timed out waiting for frame % X", want)
+			}
+		}
+	}
+
+	// 事件、指令返回和 print 输出都能收到
+	device.Emit(consts.CodeAutoSleep)
+	expectFrame([]byte{0x86, 0xFF, 0xFF, 0xFF})
+
+	if _, err := client.GetString("t0.txt"); err != nil {
+		t.Fatalf("GetString failed: %v", err)
+	}
+	expectFrame([]byte{0x70, 'h', 'i', 0xFF, 0xFF, 0xFF})
+
+	if _, err := client.Prints("t0.txt"); err != nil {
+		t.Fatalf("Prints failed: %v", err)
+	}
+	expectFrame([]byte("hi"))
+}
+
+// TestTjcDisplayClient_Passive 测试被动模式打开串口时不发送初始化指令，仍然能收到设备数据
+func TestTjcDisplayClient_Passive(t *testing.T) {
+	device := simulator.New(simulator.Config{})
+	client := newSimulatedClient(t, device)
+	client.Passive = true
+
+	if err := client.Open(); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	frames := make(chan []byte, 4)
+	unsubscribe := client.SubscribeFrames(func(frame []byte) {
+		frames <- frame
+	})
+	defer unsubscribe()
+
+	device.Emit(consts.CodeStartupSuccess)
+	select {
+	case frame := <-frames:
+		if !bytes.Equal(frame, []byte{0x88, 0xFF, 0xFF, 0xFF}) {
+			t.Errorf("Unexpected frame % X", frame)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for frame")
+	}
+
+	if history := device.History(); len(history) != 0 {
+		t.Errorf("Expected no instructions sent, got %q", history)
+	}
+	if device.ReturnMode() != 2 {
+		t.Errorf("Expected bkcmd to stay 2, got %d", device.ReturnMode())
+	}
+}
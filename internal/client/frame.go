@@ -0,0 +1,131 @@
+package client
+
+import (
+	"bytes"
+
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/consts"
+)
+
+// 固定负载长度的返回码（不含返回码和结束符），负载中可能出现 0xFF，不能只靠查找结束符分帧
+// 0x70 字符串和 print 输出长度不定，以结束符分帧
+var payloadLengths = map[byte]int{
+	consts.CodeTouchEvent:      3, // 页面ID 控件ID 状态
+	consts.CodePageID:          1, // 页面ID
+	consts.CodeTouchCoordinate: 5, // x坐标 y坐标 状态
+	consts.CodeSleepTouch:      5, // x坐标 y坐标 状态
+	consts.CodeNumberData:      4, // 小端 int32
+	consts.CodeAutoSleep:       0,
+	consts.CodeAutoWake:        0,
+	consts.CodeStartupSuccess:  0,
+	consts.CodeStartSDUpgrade:  0,
+}
+
+func init() {
+	for code := range errorMessages {
+		payloadLengths[code] = 0
+	}
+}
+
+// isBareReply 判断是否为不带结束符的单字节应答（透传就绪 0xFE、透传完成 0xFD）
+func isBareReply(code byte) bool {
+	return code == consts.CodeTransparentReady || code == consts.CodeTransparentDone
+}
+
+// FrameDecoder 流式帧解码器，按返回码的负载长度从串口数据流中切分出完整的数据帧
+//
+// 数据可以按任意长度分多次写入，不完整的帧保留到下次写入；
+// 一段时间没有新数据时调用 Flush 取出无法分帧的残留数据（如没有结束符的 print 输出）
+type FrameDecoder struct {
+	buf   []byte
+	start int
+}
+
+// Write 追加从串口读取的数据
+func (d *FrameDecoder) Write(data []byte) {
+	// 已解码的数据超过一半时整理缓冲区，避免持续增长
+	if d.start > 0 && d.start >= len(d.buf)/2 {
+		d.buf = d.buf[:copy(d.buf, d.buf[d.start:])]
+		d.start = 0
+	}
+
+	d.buf = append(d.buf, data...)
+}
+
+// Next 返回下一帧完整数据（含结束符），数据不完整时返回 nil
+func (d *FrameDecoder) Next() []byte {
+	n := frameLength(d.buf[d.start:])
+	if n < 0 {
+		return nil
+	}
+
+	frame := bytes.Clone(d.buf[d.start : d.start+n])
+	d.start += n
+	if d.start == len(d.buf) {
+		d.buf = d.buf[:0]
+		d.start = 0
+	}
+
+	return frame
+}
+
+// Flush 取出缓冲区中剩余的全部数据，没有数据时返回 nil
+func (d *FrameDecoder) Flush() []byte {
+	if d.Buffered() == 0 {
+		return nil
+	}
+
+	rest := bytes.Clone(d.buf[d.start:])
+	d.Reset()
+
+	return rest
+}
+
+// Buffered 缓冲区中尚未成帧的字节数
+func (d *FrameDecoder) Buffered() int {
+	return len(d.buf) - d.start
+}
+
+// Reset 丢弃缓冲区中的数据
+func (d *FrameDecoder) Reset() {
+	d.buf = d.buf[:0]
+	d.start = 0
+}
+
+// frameLength 返回缓冲区开头完整一帧的长度，数据不完整时返回 -1
+func frameLength(buf []byte) int {
+	if len(buf) == 0 {
+		return -1
+	}
+
+	code := buf[0]
+	if isBareReply(code) {
+		// 0xFE/0xFD 可能带结束符，也可能只有一个字节（透传模式）
+		switch {
+		case len(buf) > 1 && buf[1] != EndSymbol[0]:
+			return 1
+		case len(buf) < 1+len(EndSymbol):
+			return -1
+		case bytes.Equal(buf[1:1+len(EndSymbol)], EndSymbol):
+			return 1 + len(EndSymbol)
+		}
+		return 1
+	}
+
+	if payload, ok := payloadLengths[code]; ok {
+		size := 1 + payload + len(EndSymbol)
+		if len(buf) < size {
+			return -1
+		}
+		if bytes.Equal(buf[1+payload:size], EndSymbol) {
+			return size
+		}
+		// 长度不符（如以返回码开头的 printh 输出），退回按结束符分帧
+	}
+
+	idx := bytes.Index(buf[1:], EndSymbol)
+	if idx < 0 {
+		return -1
+	}
+
+	return 1 + idx + len(EndSymbol)
+}
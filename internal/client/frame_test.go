@@ -0,0 +1,109 @@
+package client
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestFrameDecoder 测试按返回码负载长度分帧，负载中可以出现 0xFF
+func TestFrameDecoder(t *testing.T) {
+	testCases := []struct {
+		name     string
+		stream   []byte
+		expected [][]byte
+		rest     []byte
+	}{
+		{
+			"Success",
+			[]byte{0x01, 0xFF, 0xFF, 0xFF},
+			[][]byte{{0x01, 0xFF, 0xFF, 0xFF}},
+			nil,
+		},
+		{
+			"NumberWithFF",
+			[]byte{0x71, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x01, 0xFF, 0xFF, 0xFF},
+			[][]byte{{0x71, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}, {0x01, 0xFF, 0xFF, 0xFF}},
+			nil,
+		},
+		{
+			"CoordinateWithFF",
+			[]byte{0x67, 0x00, 0xFF, 0xFF, 0xFF, 0x01, 0xFF, 0xFF, 0xFF},
+			[][]byte{{0x67, 0x00, 0xFF, 0xFF, 0xFF, 0x01, 0xFF, 0xFF, 0xFF}},
+			nil,
+		},
+		{
+			"String",
+			[]byte{0x70, 'a', 'b', 0xFF, 0xFF, 0xFF, 0x66, 0x02, 0xFF, 0xFF, 0xFF},
+			[][]byte{{0x70, 'a', 'b', 0xFF, 0xFF, 0xFF}, {0x66, 0x02, 0xFF, 0xFF, 0xFF}},
+			nil,
+		},
+		{
+			"BareTransparentReady",
+			[]byte{0xFE, 0xFD, 0xFF, 0xFF, 0xFF},
+			[][]byte{{0xFE}, {0xFD, 0xFF, 0xFF, 0xFF}},
+			nil,
+		},
+		{
+			"LengthMismatch",
+			[]byte{0x65, 0x01, 0xFF, 0xFF, 0xFF, 0x01, 0xFF, 0xFF, 0xFF},
+			[][]byte{{0x65, 0x01, 0xFF, 0xFF, 0xFF}, {0x01, 0xFF, 0xFF, 0xFF}},
+			nil,
+		},
+		{
+			"Unterminated",
+			[]byte{0x01, 0xFF, 0xFF, 0xFF, 'h', 'i'},
+			[][]byte{{0x01, 0xFF, 0xFF, 0xFF}},
+			[]byte{'h', 'i'},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// 逐字节写入，模拟分多次读到的数据
+			var decoder FrameDecoder
+			var frames [][]byte
+			for _, b := range tc.stream {
+				decoder.Write([]byte{b})
+				for frame := decoder.Next(); frame != nil; frame = decoder.Next() {
+					frames = append(frames, frame)
+				}
+			}
+
+			if rest := decoder.Flush(); !bytes.Equal(rest, tc.rest) {
+				t.Errorf("Expected rest % X, got % X", tc.rest, rest)
+			}
+
+			if len(frames) != len(tc.expected) {
+				t.Fatalf("Expected %d frames, got %d: % X", len(tc.expected), len(frames), frames)
+			}
+			for i, frame := range frames {
+				if !bytes.Equal(frame, tc.expected[i]) {
+					t.Errorf("Frame %d: expected % X, got % X", i, tc.expected[i], frame)
+				}
+			}
+		})
+	}
+}
+
+// TestFrameDecoder_Partial 测试不完整的帧保留到下次写入
+func TestFrameDecoder_Partial(t *testing.T) {
+	var decoder FrameDecoder
+	decoder.Write([]byte{0x71, 0x01, 0x00, 0x00})
+	if frame := decoder.Next(); frame != nil {
+		t.Fatalf("Expected incomplete frame, got % X", frame)
+	}
+
+	decoder.Write([]byte{0x00, 0xFF, 0xFF, 0xFF, 0x88})
+	frame := decoder.Next()
+	if !bytes.Equal(frame, []byte{0x71, 0x01, 0x00, 0x00, 0x00, 0xFF, 0xFF, 0xFF}) {
+		t.Errorf("Unexpected frame % X", frame)
+	}
+	if decoder.Buffered() != 1 {
+		t.Errorf("Expected 1 buffered byte, got %d", decoder.Buffered())
+	}
+
+	resp, err := parseResponse(frame)
+	if err != nil || resp.Code != 0x71 || len(resp.Data) != 4 {
+		t.Errorf("Unexpected response %+v, %v", resp, err)
+	}
+}
@@ -0,0 +1,322 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/internal/serial"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/consts"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/models"
+)
+
+// 按设备身份指定串口的端口名前缀
+const (
+	IdentitySerial = "serial:" // serial:<设备唯一编号>，即 connect 返回的 DeviceInfo.Number
+	IdentityUSB    = "usb:"    // usb:<VID>:<PID>[:<USB 序列号>]，来自 USB 转串口的描述符
+)
+
+// 探测单个端口和波特率的默认超时
+const defaultProbeTimeout = 200 * time.Millisecond
+
+// ErrDeviceNotFound 没有找到与身份匹配的设备
+var ErrDeviceNotFound = errors.New("device not found")
+
+// DefaultResolver 客户端未指定 Resolver 时使用的解析器，只在内存中缓存
+var DefaultResolver = &Resolver{}
+
+// IsIdentity 判断端口名是否为设备身份（serial: 或 usb:）而不是串口路径
+func IsIdentity(portName string) bool {
+	return strings.HasPrefix(portName, IdentitySerial) || strings.HasPrefix(portName, IdentityUSB)
+}
+
+// USBIdentity 返回 USB 转串口的身份，没有 USB 序列号时只包含 VID 和 PID
+func USBIdentity(port *serial.PortDetails) string {
+	identity := IdentityUSB + strings.ToLower(port.VID) + ":" + strings.ToLower(port.PID)
+	if port.SerialNumber != "" {
+		identity += ":" + port.SerialNumber
+	}
+
+	return identity
+}
+
+// Resolution 身份解析结果
+type Resolution struct {
+	PortName string    `json:"port"`                // 当前的串口路径
+	BaudRate int       `json:"baud_rate,omitempty"` // 探测到设备时的波特率（serial: 身份）
+	Time     time.Time `json:"time"`                // 解析时间
+}
+
+// Resolver 将设备身份解析为当前的串口路径（ttyUSB 编号在每次重启后都可能变化）
+//
+// usb: 身份通过枚举串口的 USB 信息直接匹配；serial: 身份需要用 Detector 并发探测各端口，
+// 解析结果会缓存下来，下次先验证缓存的端口，设备仍在原处时只需探测一次
+type Resolver struct {
+	CachePath string        // 身份与端口映射的缓存文件（JSON），为空时只缓存在内存中
+	Timeout   time.Duration // 探测单个端口和波特率的超时，默认 200ms
+	BaudRates []int         // serial: 探测时尝试的波特率及顺序，默认 LikelyBaudRates
+
+	// 以下用于测试，为空时使用系统串口
+	Opener    serial.Opener                         // 探测时打开端口的方式
+	ListPorts func() ([]*serial.PortDetails, error) // 枚举串口，默认 serial.ListPortDetails
+
+	mu     sync.Mutex
+	cache  map[string]*Resolution
+	loaded bool
+}
+
+// Resolve 解析设备身份，返回当前的串口路径
+func (r *Resolver) Resolve(ctx context.Context, identity string) (*Resolution, error) {
+	switch {
+	case strings.HasPrefix(identity, IdentityUSB):
+		return r.resolveUSB(identity)
+	case strings.HasPrefix(identity, IdentitySerial):
+		return r.resolveSerial(ctx, identity)
+	}
+
+	return nil, fmt.Errorf("invalid device identity %q", identity)
+}
+
+// resolveUSB 按 VID、PID 和 USB 序列号匹配串口，省略序列号时必须只有一个匹配的端口
+func (r *Resolver) resolveUSB(identity string) (*Resolution, error) {
+	parts := strings.SplitN(strings.TrimPrefix(identity, IdentityUSB), ":", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid device identity %q, expected usb:<vid>:<pid>[:<serial>]", identity)
+	}
+
+	ports, err := r.listPorts()
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []string
+	for _, port := range ports {
+		if !port.IsUSB || !strings.EqualFold(port.VID, parts[0]) || !strings.EqualFold(port.PID, parts[1]) {
+			continue
+		}
+		if len(parts) == 3 && port.SerialNumber != parts[2] {
+			continue
+		}
+		matched = append(matched, port.Name)
+	}
+
+	switch len(matched) {
+	case 0:
+		return nil, fmt.Errorf("%w: %s", ErrDeviceNotFound, identity)
+	case 1:
+		return r.store(identity, &Resolution{PortName: matched[0], Time: time.Now()}), nil
+	}
+
+	return nil, fmt.Errorf("%s matches %d ports (%s), add the USB serial number", identity, len(matched), strings.Join(matched, ", "))
+}
+
+// resolveSerial 先验证缓存的端口，设备不在原处时用 Detector 探测所有端口
+func (r *Resolver) resolveSerial(ctx context.Context, identity string) (*Resolution, error) {
+	number := strings.TrimPrefix(identity, IdentitySerial)
+	if number == "" {
+		return nil, fmt.Errorf("invalid device identity %q, expected serial:<number>", identity)
+	}
+
+	cached := r.lookup(identity)
+	if cached != nil {
+		info, err := r.probe(ctx, cached.PortName, cached.BaudRate)
+		if err == nil && info.Number == number {
+			return r.store(identity, &Resolution{PortName: cached.PortName, BaudRate: cached.BaudRate, Time: time.Now()}), nil
+		}
+	}
+
+	detector := &Detector{
+		Timeout:   r.timeout(),
+		BaudRates: r.baudRates(cached),
+		Opener:    r.Opener,
+		ListPorts: r.listPorts,
+	}
+	devices, err := detector.Detect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *Resolution
+	for _, device := range devices {
+		// 顺带记录探测到的其他设备，之后解析它们时只需验证
+		resolution := r.store(IdentitySerial+device.Info.Number, &Resolution{PortName: device.PortName, BaudRate: device.BaudRate, Time: time.Now()})
+		if device.Info.Number == number {
+			found = resolution
+		}
+	}
+	if found != nil {
+		return found, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrDeviceNotFound, identity)
+}
+
+// baudRates 探测顺序：缓存的波特率优先
+func (r *Resolver) baudRates(cached *Resolution) []int {
+	baudRates := r.BaudRates
+	if len(baudRates) == 0 {
+		baudRates = LikelyBaudRates
+	}
+
+	if cached == nil || cached.BaudRate == 0 {
+		return baudRates
+	}
+
+	ordered := []int{cached.BaudRate}
+	for _, baudRate := range baudRates {
+		if baudRate != cached.BaudRate {
+			ordered = append(ordered, baudRate)
+		}
+	}
+
+	return ordered
+}
+
+// probe 以指定波特率连接端口并获取设备信息
+func (r *Resolver) probe(ctx context.Context, portName string, baudRate int) (*models.DeviceInfo, error) {
+	return probeDevice(ctx, r.Opener, portName, baudRate, r.timeout())
+}
+
+func (r *Resolver) timeout() time.Duration {
+	if r.Timeout <= 0 {
+		return defaultProbeTimeout
+	}
+
+	return r.Timeout
+}
+
+func (r *Resolver) listPorts() ([]*serial.PortDetails, error) {
+	listPorts := r.ListPorts
+	if listPorts == nil {
+		listPorts = serial.ListPortDetails
+	}
+
+	ports, err := listPorts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ports: %w", err)
+	}
+
+	return ports, nil
+}
+
+func (r *Resolver) lookup(identity string) *Resolution {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.load()
+	return r.cache[identity]
+}
+
+// store 记录解析结果并写入缓存文件，写入失败不影响解析
+func (r *Resolver) store(identity string, resolution *Resolution) *Resolution {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.load()
+	r.cache[identity] = resolution
+
+	// 同一端口上不会同时存在两个设备，清理指向该端口的旧记录
+	for other, cached := range r.cache {
+		if other != identity && cached.PortName == resolution.PortName && strings.HasPrefix(other, IdentitySerial) == strings.HasPrefix(identity, IdentitySerial) {
+			delete(r.cache, other)
+		}
+	}
+
+	_ = r.save()
+
+	return resolution
+}
+
+// load 首次使用时读取缓存文件，调用方需持有 mu
+func (r *Resolver) load() {
+	if r.loaded {
+		return
+	}
+	r.loaded = true
+	r.cache = make(map[string]*Resolution)
+
+	if r.CachePath == "" {
+		return
+	}
+
+	data, err := os.ReadFile(r.CachePath)
+	if err != nil {
+		return
+	}
+
+	_ = json.Unmarshal(data, &r.cache)
+	if r.cache == nil {
+		r.cache = make(map[string]*Resolution)
+	}
+}
+
+// save 写入缓存文件，调用方需持有 mu
+func (r *Resolver) save() error {
+	if r.CachePath == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(r.cache, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(r.CachePath), 0o755)
+	if err != nil {
+		return err
+	}
+
+	// 先写临时文件再重命名，避免多个进程同时写入时文件损坏
+	tmp := r.CachePath + ".tmp"
+	err = os.WriteFile(tmp, data, 0o644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, r.CachePath)
+}
+
+// Identities 返回缓存中的所有身份及其最近一次解析结果
+func (r *Resolver) Identities() map[string]Resolution {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.load()
+	identities := make(map[string]Resolution, len(r.cache))
+	for identity, resolution := range r.cache {
+		identities[identity] = *resolution
+	}
+
+	return identities
+}
+
+// resolvePort 返回实际打开的串口路径，端口名为设备身份时通过解析器解析，
+// serial: 身份同时采用探测到的波特率
+func (c *TjcDisplayClient) resolvePort(ctx context.Context) (string, error) {
+	if !IsIdentity(c.PortName) {
+		return c.PortName, nil
+	}
+
+	resolver := c.Resolver
+	if resolver == nil {
+		resolver = DefaultResolver
+	}
+
+	resolution, err := resolver.Resolve(ctx, c.PortName)
+	if err != nil {
+		return "", err
+	}
+
+	if resolution.BaudRate != 0 && slices.Contains(consts.SupportedBaudrate, resolution.BaudRate) {
+		c.BaudRate = resolution.BaudRate
+	}
+
+	return resolution.PortName, nil
+}
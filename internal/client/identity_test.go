@@ -0,0 +1,257 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/internal/serial"
+	"github.com/blue-cloud-net/tjc-serial-display/internal/simulator"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/models"
+	goserial "go.bug.st/serial"
+)
+
+// testPorts 按端口名分发到模拟设备的串口集合，可以模拟设备重新插入后端口改变
+type testPorts struct {
+	mu      sync.Mutex
+	devices map[string]*simulator.Device
+	details map[string]*serial.PortDetails
+}
+
+func newTestPorts() *testPorts {
+	return &testPorts{
+		devices: make(map[string]*simulator.Device),
+		details: make(map[string]*serial.PortDetails),
+	}
+}
+
+// attach 将设备接到指定端口，details 为空时端口不是 USB 转串口
+func (p *testPorts) attach(name string, device *simulator.Device, details *serial.PortDetails) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if details == nil {
+		details = &serial.PortDetails{}
+	}
+	details.Name = name
+	p.devices[name] = device
+	p.details[name] = details
+}
+
+func (p *testPorts) detach(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.devices, name)
+	delete(p.details, name)
+}
+
+func (p *testPorts) list() ([]*serial.PortDetails, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ports := make([]*serial.PortDetails, 0, len(p.details))
+	for _, details := range p.details {
+		ports = append(ports, details)
+	}
+
+	return ports, nil
+}
+
+func (p *testPorts) opener() serial.Opener {
+	return func(portName string, mode *goserial.Mode) (serial.Transport, error) {
+		p.mu.Lock()
+		device := p.devices[portName]
+		p.mu.Unlock()
+
+		if device == nil {
+			return nil, os.ErrNotExist
+		}
+
+		return device.Opener()(portName, mode)
+	}
+}
+
+func (p *testPorts) resolver(cachePath string) *Resolver {
+	return &Resolver{
+		CachePath: cachePath,
+		Timeout:   50 * time.Millisecond,
+		BaudRates: []int{9600, 115200},
+		Opener:    p.opener(),
+		ListPorts: p.list,
+	}
+}
+
+func newNumberedDevice(number string, baudRate int) *simulator.Device {
+	return simulator.New(simulator.Config{
+		Info:       models.DeviceInfo{Type: 1, Address: "101-0", Model: "TJC4024T032_011R", Number: number},
+		BaudRate:   baudRate,
+		ReturnMode: 3,
+	})
+}
+
+// TestResolver_SerialNumber 测试按设备唯一编号解析端口，端口改变后重新探测
+func TestResolver_SerialNumber(t *testing.T) {
+	ports := newTestPorts()
+	ports.attach("/dev/ttyUSB0", newNumberedDevice("AAAA000000000001", 115200), nil)
+	second := newNumberedDevice("BBBB000000000002", 9600)
+	ports.attach("/dev/ttyUSB1", second, nil)
+
+	cachePath := filepath.Join(t.TempDir(), "identities.json")
+	resolver := ports.resolver(cachePath)
+
+	resolution, err := resolver.Resolve(context.Background(), "serial:BBBB000000000002")
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if resolution.PortName != "/dev/ttyUSB1" || resolution.BaudRate != 9600 {
+		t.Errorf("Unexpected resolution: %+v", resolution)
+	}
+
+	// 设备重新插入后变为 ttyUSB2
+	ports.detach("/dev/ttyUSB1")
+	ports.attach("/dev/ttyUSB2", second, nil)
+
+	resolution, err = resolver.Resolve(context.Background(), "serial:BBBB000000000002")
+	if err != nil || resolution.PortName != "/dev/ttyUSB2" {
+		t.Errorf("Expected /dev/ttyUSB2 after rename, got %+v, %v", resolution, err)
+	}
+
+	_, err = resolver.Resolve(context.Background(), "serial:CCCC000000000003")
+	if !errors.Is(err, ErrDeviceNotFound) {
+		t.Errorf("Expected ErrDeviceNotFound, got %v", err)
+	}
+
+	// 新的解析器从缓存文件读取映射，包括探测时顺带发现的设备
+	identities := ports.resolver(cachePath).Identities()
+	if identities["serial:BBBB000000000002"].PortName != "/dev/ttyUSB2" {
+		t.Errorf("Expected cached /dev/ttyUSB2, got %+v", identities)
+	}
+	if cached := identities["serial:AAAA000000000001"]; cached.PortName != "/dev/ttyUSB0" || cached.BaudRate != 115200 {
+		t.Errorf("Expected cached /dev/ttyUSB0, got %+v", identities)
+	}
+}
+
+// TestResolver_SerialNumberConcurrent 测试多个端口上同时探测，耗时不随端口数线性增长
+func TestResolver_SerialNumberConcurrent(t *testing.T) {
+	ports := newTestPorts()
+	for i := range 6 {
+		// 波特率不在探测列表中，每次探测都要等到超时
+		ports.attach(fmt.Sprintf("/dev/ttyS%d", i), newNumberedDevice(fmt.Sprintf("CCCC00000000000%d", i), 2400), nil)
+	}
+	ports.attach("/dev/ttyUSB0", newNumberedDevice("AAAA000000000001", 9600), nil)
+
+	resolver := ports.resolver("")
+	resolver.BaudRates = []int{115200, 9600}
+
+	start := time.Now()
+	resolution, err := resolver.Resolve(context.Background(), "serial:AAAA000000000001")
+	if err != nil || resolution.PortName != "/dev/ttyUSB0" {
+		t.Fatalf("Expected /dev/ttyUSB0, got %+v, %v", resolution, err)
+	}
+
+	// 逐个探测 6 个没有应答的端口 × 2 个波特率 × 50ms 就需要 600ms
+	if elapsed := time.Since(start); elapsed > 300*time.Millisecond {
+		t.Errorf("Expected ports to be probed concurrently, took %v", elapsed)
+	}
+}
+
+// TestResolver_USB 测试按 USB VID、PID 和序列号解析端口
+func TestResolver_USB(t *testing.T) {
+	ports := newTestPorts()
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	ports.attach("/dev/ttyUSB0", device, &serial.PortDetails{IsUSB: true, VID: "1A86", PID: "7523", SerialNumber: "A1"})
+	ports.attach("/dev/ttyUSB1", device, &serial.PortDetails{IsUSB: true, VID: "1A86", PID: "7523", SerialNumber: "B2"})
+	ports.attach("/dev/ttyACM0", device, &serial.PortDetails{IsUSB: true, VID: "0483", PID: "5740"})
+	resolver := ports.resolver("")
+
+	tests := []struct {
+		identity string
+		expected string
+		wantErr  bool
+	}{
+		{"usb:1a86:7523:B2", "/dev/ttyUSB1", false},
+		{"usb:0483:5740", "/dev/ttyACM0", false},
+		{"usb:1a86:7523", "", true},    // 两个端口匹配
+		{"usb:1a86:7523:C3", "", true}, // 序列号不匹配
+		{"usb:1a86", "", true},         // 格式错误
+	}
+
+	for _, tt := range tests {
+		resolution, err := resolver.Resolve(context.Background(), tt.identity)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected error, got %+v", tt.identity, resolution)
+			}
+			continue
+		}
+		if err != nil || resolution.PortName != tt.expected {
+			t.Errorf("%s: expected %s, got %+v, %v", tt.identity, tt.expected, resolution, err)
+		}
+	}
+
+	details, _ := ports.list()
+	for _, port := range details {
+		if port.Name == "/dev/ttyUSB0" && USBIdentity(port) != "usb:1a86:7523:A1" {
+			t.Errorf("Unexpected USB identity %s", USBIdentity(port))
+		}
+	}
+}
+
+// TestTjcDisplayClient_OpenByIdentity 测试按身份打开客户端，重连时重新解析端口
+func TestTjcDisplayClient_OpenByIdentity(t *testing.T) {
+	ports := newTestPorts()
+	device := newNumberedDevice("D264B8204F0E1828", 9600)
+	ports.attach("/dev/ttyUSB0", device, nil)
+
+	client := &TjcDisplayClient{
+		PortName:             "serial:D264B8204F0E1828",
+		BaudRate:             115200,
+		Timeout:              200 * time.Millisecond,
+		Opener:               ports.opener(),
+		Resolver:             ports.resolver(""),
+		AutoReconnect:        true,
+		MaxReconnectInterval: time.Second,
+	}
+	defer client.Close()
+
+	connected := make(chan bool, 4)
+	unsubscribe := client.SubscribeConnection(func(event *models.ConnectionEvent) {
+		connected <- event.Connected
+	})
+	defer unsubscribe()
+
+	info, err := client.GetDeviceInfo()
+	if err != nil {
+		t.Fatalf("GetDeviceInfo failed: %v", err)
+	}
+	if info.Number != "D264B8204F0E1828" || client.BaudRate != 9600 {
+		t.Errorf("Unexpected device %s at %d baud", info.Number, client.BaudRate)
+	}
+
+	// 拔出后重新插入到另一个端口
+	device.Unplug()
+	ports.detach("/dev/ttyUSB0")
+	ports.attach("/dev/ttyUSB3", device, nil)
+	device.Plug()
+
+	for _, expected := range []bool{false, true} {
+		select {
+		case state := <-connected:
+			if state != expected {
+				t.Fatalf("Expected connected=%v, got %v", expected, state)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Timeout waiting for connected=%v", expected)
+		}
+	}
+
+	if _, err := client.GetPage(); err != nil {
+		t.Errorf("GetPage after rename failed: %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"sync"
+)
+
+// ctxLock 互斥锁，等待加锁时可通过 context 取消，零值可用
+type ctxLock struct {
+	once sync.Once
+	ch   chan struct{}
+}
+
+func (l *ctxLock) init() {
+	l.once.Do(func() {
+		l.ch = make(chan struct{}, 1)
+	})
+}
+
+func (l *ctxLock) Lock() {
+	l.init()
+	l.ch <- struct{}{}
+}
+
+// LockContext 加锁，context 取消时放弃等待并返回其错误
+func (l *ctxLock) LockContext(ctx context.Context) error {
+	l.init()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	select {
+	case l.ch <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *ctxLock) Unlock() {
+	<-l.ch
+}
@@ -0,0 +1,155 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/models"
+)
+
+// ErrSleeping 设备处于睡眠模式，指令被设备忽略没有返回
+var ErrSleeping = errors.New("device is sleeping")
+
+// 自动睡眠时间的范围（秒），0 表示关闭
+const (
+	minAutoSleep = 3
+	maxAutoSleep = 65535
+)
+
+// 睡眠时设备仍然响应的指令：系统变量赋值和 connect
+var sleepInstructions = []string{"sleep", "dim", "dims", "baud", "bauds", "thsp", "ussp", "thup", "usup", "bkcmd", "wup", "connect", exitActiveParse}
+
+// IsSleeping 设备是否处于睡眠模式，由 Sleep/Wake 和设备上报的 0x86/0x87 事件维护
+func (c *TjcDisplayClient) IsSleeping() bool {
+	return c.sleeping.Load()
+}
+
+// Sleep 进入睡眠模式（sleep=1），屏幕关闭，除系统变量赋值外的指令均被忽略
+func (c *TjcDisplayClient) Sleep() error {
+	err := c.Execute("sleep=1")
+	if err != nil {
+		return err
+	}
+
+	c.sleeping.Store(true)
+	return nil
+}
+
+// Wake 退出睡眠模式（sleep=0）
+func (c *TjcDisplayClient) Wake() error {
+	err := c.connect(context.Background())
+	if err != nil {
+		return err
+	}
+
+	return c.wake(context.Background())
+}
+
+func (c *TjcDisplayClient) wake(ctx context.Context) error {
+	err := c.sendCommand(ctx, "sleep=0", false)
+	if err != nil {
+		return err
+	}
+
+	c.sleeping.Store(false)
+	return nil
+}
+
+// SetAutoSleep 设置无触摸（thsp）和无串口数据（ussp）多久后自动进入睡眠，精确到秒，
+// 0 表示关闭，否则范围为 3s 到 65535s
+func (c *TjcDisplayClient) SetAutoSleep(noTouch, noSerial time.Duration) error {
+	for _, v := range []struct {
+		name  string
+		value time.Duration
+	}{{"thsp", noTouch}, {"ussp", noSerial}} {
+		seconds := int(v.value.Round(time.Second) / time.Second)
+		if seconds != 0 && (seconds < minAutoSleep || seconds > maxAutoSleep) {
+			return fmt.Errorf("%s %v out of range, expected 0 or %ds to %ds", v.name, v.value, minAutoSleep, maxAutoSleep)
+		}
+
+		err := c.Execute(fmt.Sprintf("%s=%d", v.name, seconds))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetWakeOnTouch 设置睡眠时触摸是否唤醒设备（thup）
+func (c *TjcDisplayClient) SetWakeOnTouch(enabled bool) error {
+	return c.Execute(fmt.Sprintf("thup=%d", boolToInt(enabled)))
+}
+
+// SetWakeOnSerial 设置睡眠时收到串口数据是否唤醒设备（usup）
+func (c *TjcDisplayClient) SetWakeOnSerial(enabled bool) error {
+	return c.Execute(fmt.Sprintf("usup=%d", boolToInt(enabled)))
+}
+
+// SetBacklight 设置背光亮度（0-100），persistent 为 true 时使用 dims 同时保存为上电默认亮度
+func (c *TjcDisplayClient) SetBacklight(percent int, persistent bool) error {
+	if percent < 0 || percent > 100 {
+		return fmt.Errorf("backlight %d out of range, expected 0 to 100", percent)
+	}
+
+	variable := "dim"
+	if persistent {
+		variable = "dims"
+	}
+
+	return c.Execute(fmt.Sprintf("%s=%d", variable, percent))
+}
+
+// checkAwake 发送指令前检查设备是否睡眠，开启 AutoWake 时先唤醒设备
+func (c *TjcDisplayClient) checkAwake(ctx context.Context, cmd string) error {
+	if !c.AutoWake || !c.sleeping.Load() || isSleepInstruction(cmd) {
+		return nil
+	}
+
+	return c.wake(ctx)
+}
+
+// sleepError 设备睡眠时忽略指令，将没有返回的超时转为 ErrSleeping
+func (c *TjcDisplayClient) sleepError(cmd string, err error) error {
+	if errors.Is(err, errReadTimeout) && c.sleeping.Load() && !isSleepInstruction(cmd) {
+		return fmt.Errorf("%w: %w", ErrSleeping, err)
+	}
+
+	return err
+}
+
+// implicitSuccess 没有返回的指令视为执行成功，但设备睡眠时指令实际被忽略
+func (c *TjcDisplayClient) implicitSuccess(cmd string) error {
+	if c.sleeping.Load() && !isSleepInstruction(cmd) {
+		return ErrSleeping
+	}
+
+	return nil
+}
+
+func isSleepInstruction(cmd string) bool {
+	name, _, _ := strings.Cut(cmd, "=")
+	return slices.Contains(sleepInstructions, strings.TrimSpace(name))
+}
+
+// trackPower 根据设备上报的事件更新睡眠状态
+func (c *TjcDisplayClient) trackPower(event *models.Event) {
+	switch event.Type {
+	case models.EventAutoSleep:
+		c.sleeping.Store(true)
+	case models.EventAutoWake, models.EventStartup:
+		c.sleeping.Store(false)
+	}
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
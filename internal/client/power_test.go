@@ -0,0 +1,135 @@
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/internal/simulator"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/models"
+)
+
+// TestTjcDisplayClient_SleepWake 测试睡眠时指令返回 ErrSleeping，唤醒后恢复
+func TestTjcDisplayClient_SleepWake(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	client := newSimulatedClient(t, device)
+
+	if err := client.Sleep(); err != nil {
+		t.Fatalf("Sleep failed: %v", err)
+	}
+	if !device.Sleeping() || !client.IsSleeping() {
+		t.Fatalf("Expected sleeping, device %v, client %v", device.Sleeping(), client.IsSleeping())
+	}
+
+	if _, err := client.GetPage(); !errors.Is(err, ErrSleeping) {
+		t.Errorf("Expected ErrSleeping, got %v", err)
+	}
+
+	// 系统变量赋值在睡眠时仍然有效
+	if err := client.SetBacklight(30, false); err != nil {
+		t.Errorf("SetBacklight while sleeping failed: %v", err)
+	}
+	if dim := device.SysVar("dim"); dim != 30 {
+		t.Errorf("Expected dim 30, got %d", dim)
+	}
+
+	if err := client.Wake(); err != nil {
+		t.Fatalf("Wake failed: %v", err)
+	}
+	if device.Sleeping() || client.IsSleeping() {
+		t.Errorf("Expected awake, device %v, client %v", device.Sleeping(), client.IsSleeping())
+	}
+	if _, err := client.GetPage(); err != nil {
+		t.Errorf("GetPage after wake failed: %v", err)
+	}
+}
+
+// TestTjcDisplayClient_AutoWake 测试开启 AutoWake 时发送指令前自动唤醒设备
+func TestTjcDisplayClient_AutoWake(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	client := newSimulatedClient(t, device)
+	client.AutoWake = true
+
+	if err := client.Sleep(); err != nil {
+		t.Fatalf("Sleep failed: %v", err)
+	}
+
+	page, err := client.GetPage()
+	if err != nil || page != 0 {
+		t.Errorf("Expected page 0 after auto wake, got %d, %v", page, err)
+	}
+	if device.Sleeping() {
+		t.Error("Expected device to be woken")
+	}
+}
+
+// TestTjcDisplayClient_PowerEvents 测试根据 0x86/0x87 事件跟踪睡眠状态
+func TestTjcDisplayClient_PowerEvents(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	client := newSimulatedClient(t, device)
+
+	events, cancel := client.Events(4)
+	defer cancel()
+
+	if err := client.Open(); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if err := client.SetWakeOnTouch(true); err != nil {
+		t.Fatalf("SetWakeOnTouch failed: %v", err)
+	}
+
+	waitEvent := func(expected models.EventType) {
+		t.Helper()
+		select {
+		case event := <-events:
+			if event.Type != expected {
+				t.Fatalf("Expected %v event, got %v", expected, event.Type)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Timeout waiting for %v event", expected)
+		}
+	}
+
+	device.AutoSleep()
+	waitEvent(models.EventAutoSleep)
+	if !client.IsSleeping() {
+		t.Error("Expected sleeping after auto sleep event")
+	}
+
+	// thup=1 时触摸唤醒设备
+	device.Touch(0, 1, true)
+	waitEvent(models.EventAutoWake)
+	if client.IsSleeping() {
+		t.Error("Expected awake after auto wake event")
+	}
+}
+
+// TestTjcDisplayClient_PowerSettings 测试自动睡眠、唤醒方式和背光设置
+func TestTjcDisplayClient_PowerSettings(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	client := newSimulatedClient(t, device)
+
+	if err := client.SetAutoSleep(30*time.Second, 0); err != nil {
+		t.Fatalf("SetAutoSleep failed: %v", err)
+	}
+	if err := client.SetWakeOnSerial(true); err != nil {
+		t.Fatalf("SetWakeOnSerial failed: %v", err)
+	}
+	if err := client.SetBacklight(80, true); err != nil {
+		t.Fatalf("SetBacklight failed: %v", err)
+	}
+
+	expected := map[string]int32{"thsp": 30, "ussp": 0, "usup": 1, "dims": 80}
+	for name, value := range expected {
+		if got := device.SysVar(name); got != value {
+			t.Errorf("Expected %s=%d, got %d", name, value, got)
+		}
+	}
+
+	if err := client.SetAutoSleep(time.Second, 0); err == nil {
+		t.Error("Expected error for auto sleep below 3s")
+	}
+	if err := client.SetBacklight(101, false); err == nil {
+		t.Error("Expected error for backlight above 100")
+	}
+}
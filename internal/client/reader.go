@@ -0,0 +1,169 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// 后台读取协程的轮询间隔，同时也是无结束符数据（如 print 输出）的判定时间
+const readerPollInterval = 50 * time.Millisecond
+
+// 默认的指令响应超时
+const defaultTimeout = 2 * time.Second
+
+var errReadTimeout = errors.New("read timeout or no data")
+
+// timeout 指令响应超时
+func (c *TjcDisplayClient) timeout() time.Duration {
+	if c.Timeout > 0 {
+		return c.Timeout
+	}
+
+	return defaultTimeout
+}
+
+// startReader 启动后台读取协程，负责将主动上报的事件与指令返回分离
+func (c *TjcDisplayClient) startReader() {
+	if c.readerStop != nil {
+		return
+	}
+
+	if c.responses == nil {
+		c.responses = make(chan []byte, 16)
+	}
+
+	_ = c.serialManager.SetReadTimeout(readerPollInterval)
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	c.readerStop = stop
+	c.readerDone = done
+	c.readErr.Store(nil)
+
+	go c.readLoop(stop, done)
+}
+
+// stopReader 停止后台读取协程并恢复串口读超时，用于升级等需要直接读取串口的场景
+func (c *TjcDisplayClient) stopReader() {
+	if c.readerStop == nil {
+		return
+	}
+
+	close(c.readerStop)
+	<-c.readerDone
+	c.readerStop = nil
+	c.readerDone = nil
+
+	if c.serialManager != nil && c.serialManager.IsOpen() {
+		_ = c.serialManager.SetReadTimeout(c.timeout())
+	}
+}
+
+func (c *TjcDisplayClient) readLoop(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	var decoder FrameDecoder
+	for {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+
+		data, err := c.serialManager.Read()
+		if err != nil {
+			select {
+			case <-stop:
+			default:
+				c.readErr.Store(&err)
+			}
+			return
+		}
+
+		if len(data) == 0 {
+			// 一个轮询周期内没有新数据，无法分帧的残留数据（如没有结束符的 print 输出）作为一帧处理
+			if rest := decoder.Flush(); rest != nil {
+				c.publishFrame(rest)
+				c.dispatch(rest)
+			}
+			continue
+		}
+
+		decoder.Write(data)
+		for frame := decoder.Next(); frame != nil; frame = decoder.Next() {
+			c.publishFrame(frame)
+			c.dispatch(frame)
+		}
+	}
+}
+
+// ReadErr 返回后台读取协程因串口错误（如设备拔出、网络串口断开）退出时的错误，
+// 读取正常时返回 nil。之后的指令会尝试重新打开串口，开启 AutoReconnect 时在后台自动重连
+func (c *TjcDisplayClient) ReadErr() error {
+	if errPtr := c.readErr.Load(); errPtr != nil {
+		return *errPtr
+	}
+
+	return nil
+}
+
+// dispatch 分发一帧数据：主动上报的事件交给订阅者，其余作为指令返回
+func (c *TjcDisplayClient) dispatch(frame []byte) {
+	resp, err := parseResponse(frame)
+	if err == nil && resp.Type == ResponseTypeEvent {
+		// 没有等待中的指令时，页面ID也视为主动上报
+		if isUnsolicited(resp.Code) || !c.pending.Load() {
+			if event, ok := parseEvent(resp); ok {
+				c.trackPower(event)
+				c.publish(event)
+			}
+			return
+		}
+	}
+
+	c.deliver(frame)
+}
+
+// deliver 将数据交给等待中的指令，无人等待时直接丢弃
+func (c *TjcDisplayClient) deliver(frame []byte) {
+	if !c.pending.Load() {
+		return
+	}
+
+	select {
+	case c.responses <- frame:
+	default:
+	}
+}
+
+// discardResponses 清理上一条指令遗留的返回数据
+func (c *TjcDisplayClient) discardResponses() {
+	for {
+		select {
+		case <-c.responses:
+		default:
+			return
+		}
+	}
+}
+
+// waitResponse 在 wait 时间内等待一帧指令返回（含结束符），context 取消时立即返回
+func (c *TjcDisplayClient) waitResponse(ctx context.Context, wait time.Duration) ([]byte, error) {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case frame := <-c.responses:
+		return frame, nil
+	case <-c.readerDone:
+		if errPtr := c.readErr.Load(); errPtr != nil {
+			return nil, *errPtr
+		}
+		return nil, errors.New("reader stopped")
+	case <-timer.C:
+		return nil, errReadTimeout
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
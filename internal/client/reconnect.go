@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/models"
+)
+
+// 自动重连的退避间隔
+const (
+	minReconnectInterval     = 500 * time.Millisecond
+	defaultReconnectInterval = 10 * time.Second
+)
+
+// IsConnected 串口已打开且设备没有断开
+func (c *TjcDisplayClient) IsConnected() bool {
+	return c.serialManager != nil && !c.closed.Load() && c.serialManager.IsOpen()
+}
+
+// handleDisconnect 串口检测到设备断开后调用：通知订阅者，开启自动重连时在后台重新打开串口
+func (c *TjcDisplayClient) handleDisconnect(err error) {
+	if c.closed.Load() {
+		return
+	}
+
+	c.disconnected.Store(true)
+	c.publishConnection(&models.ConnectionEvent{Connected: false, Err: err, Time: time.Now()})
+
+	if !c.AutoReconnect {
+		return
+	}
+
+	c.reconnectLock.Lock()
+	defer c.reconnectLock.Unlock()
+
+	// 重连协程仍在运行
+	if c.reconnectDone != nil {
+		select {
+		case <-c.reconnectDone:
+		default:
+			return
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	c.reconnectCancel = cancel
+	c.reconnectDone = done
+
+	go c.reconnectLoop(ctx, done)
+}
+
+// reconnectLoop 按退避间隔重新打开串口，直到成功（包括其他指令触发的重连）或客户端关闭
+func (c *TjcDisplayClient) reconnectLoop(ctx context.Context, done chan<- struct{}) {
+	defer close(done)
+
+	interval := minReconnectInterval
+	for c.disconnected.Load() {
+		timer := time.NewTimer(interval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+
+		if c.connect(ctx) == nil {
+			return
+		}
+
+		interval = min(interval*2, c.maxReconnectInterval())
+	}
+}
+
+// stopReconnect 停止后台重连并等待重连协程退出
+func (c *TjcDisplayClient) stopReconnect() {
+	c.reconnectLock.Lock()
+	cancel := c.reconnectCancel
+	done := c.reconnectDone
+	c.reconnectCancel = nil
+	c.reconnectDone = nil
+	c.reconnectLock.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+}
+
+func (c *TjcDisplayClient) maxReconnectInterval() time.Duration {
+	if c.MaxReconnectInterval > 0 {
+		return c.MaxReconnectInterval
+	}
+
+	return defaultReconnectInterval
+}
+
+// SubscribeConnection 订阅串口连接状态变化（设备断开、重新连接），返回取消订阅函数
+// 回调在检测到断开的读写协程或重连协程中执行，不应长时间阻塞
+func (c *TjcDisplayClient) SubscribeConnection(callback models.ConnectionCallback) func() {
+	c.subLock.Lock()
+	defer c.subLock.Unlock()
+
+	if c.connSubscribers == nil {
+		c.connSubscribers = make(map[int]models.ConnectionCallback)
+	}
+
+	id := c.nextSubID
+	c.nextSubID++
+	c.connSubscribers[id] = callback
+
+	return func() {
+		c.subLock.Lock()
+		defer c.subLock.Unlock()
+
+		delete(c.connSubscribers, id)
+	}
+}
+
+// publishConnection 将连接状态变化分发给所有订阅者
+func (c *TjcDisplayClient) publishConnection(event *models.ConnectionEvent) {
+	c.subLock.Lock()
+	callbacks := make([]models.ConnectionCallback, 0, len(c.connSubscribers))
+	for _, callback := range c.connSubscribers {
+		callbacks = append(callbacks, callback)
+	}
+	c.subLock.Unlock()
+
+	for _, callback := range callbacks {
+		callback(event)
+	}
+}
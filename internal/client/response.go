@@ -1,6 +1,9 @@
 package client
 
-import "fmt"
+import (
+	"bytes"
+	"fmt"
+)
 
 // TjcError TJC串口屏错误
 type TjcError struct {
@@ -30,6 +33,11 @@ func (e *TjcError) Error() string {
 	return fmt.Sprintf("TJC Error 0x%02X: %s", e.Code, e.Message)
 }
 
+// ErrorCode 设备返回的错误码
+func (e *TjcError) ErrorCode() byte {
+	return e.Code
+}
+
 // toError 将响应转换为错误
 func (r *Response) toError() error {
 	if r.Type == ResponseTypeError {
@@ -67,3 +75,17 @@ var errorMessages = map[byte]string{
 	0x23: "变量名称太长",
 	0x24: "串口缓冲区溢出",
 }
+
+// ParseResponse 解析一帧设备返回数据，结束符可有可无
+func ParseResponse(frame []byte) (*Response, error) {
+	if len(frame) > 0 && !bytes.HasSuffix(frame, EndSymbol) && !(len(frame) == 1 && isBareReply(frame[0])) {
+		frame = append(bytes.Clone(frame), EndSymbol...)
+	}
+
+	return parseResponse(frame)
+}
+
+// Err 错误响应转换为 *TjcError，其余响应返回 nil
+func (r *Response) Err() error {
+	return r.toError()
+}
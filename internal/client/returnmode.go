@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReturnMode 设备返回指令执行结果的方式（bkcmd）
+type ReturnMode int
+
+const (
+	ReturnNone    ReturnMode = 0 // 不返回
+	ReturnSuccess ReturnMode = 1 // 只在成功时返回
+	ReturnFailure ReturnMode = 2 // 只在失败时返回，设备上电默认值
+	ReturnAll     ReturnMode = 3 // 总是返回
+)
+
+// failureWindow 只在失败时返回（bkcmd=2）时等待错误码的时间，超过后视为执行成功
+const failureWindow = 100 * time.Millisecond
+
+// 总是返回数据的查询指令，不受 bkcmd 影响
+var queryInstructions = []string{"get", "print", "printh", "sendme", "connect"}
+
+// ReturnMode 客户端使用的设备返回方式：调用方通过 SetReturnMode 指定的值，
+// 未指定时为打开串口时从设备读取的 bkcmd，尚未读取时按设备上电默认值 ReturnFailure 处理
+func (c *TjcDisplayClient) ReturnMode() ReturnMode {
+	if v := c.returnMode.Load(); v > 0 {
+		return ReturnMode(v - 1)
+	}
+
+	return ReturnFailure
+}
+
+// SetReturnMode 设置设备返回指令执行结果的方式（bkcmd），重新打开串口后依然有效
+//
+// ReturnAll 下每条指令都等待设备返回；ReturnFailure 下成功的指令在短时间内没有返回即视为成功，
+// 失败仍然返回 TjcError；ReturnNone 下指令发送后立即返回，设备错误无法得知
+func (c *TjcDisplayClient) SetReturnMode(mode ReturnMode) error {
+	if mode < ReturnNone || mode > ReturnAll {
+		return fmt.Errorf("invalid return mode %d, expected 0 to 3", mode)
+	}
+
+	c.storeReturnMode(mode)
+	c.explicitMode.Store(true)
+
+	return c.Execute(fmt.Sprintf("bkcmd=%d", mode))
+}
+
+func (c *TjcDisplayClient) storeReturnMode(mode ReturnMode) {
+	c.returnMode.Store(int32(mode) + 1)
+}
+
+// syncReturnMode 打开串口后同步返回方式：调用方指定过返回方式时设置到设备，
+// 否则读取设备当前的 bkcmd，不改变设备状态
+func (c *TjcDisplayClient) syncReturnMode(ctx context.Context) {
+	if c.explicitMode.Load() {
+		_ = c.sendCommand(ctx, fmt.Sprintf("bkcmd=%d", c.ReturnMode()), false)
+		return
+	}
+
+	c.returnMode.Store(0)
+
+	resp, err := c.sendCommandAndWaitResponse(ctx, "get bkcmd", false)
+	if err != nil {
+		return
+	}
+
+	mode, err := decodeNumber(resp)
+	if err == nil && mode >= int32(ReturnNone) && mode <= int32(ReturnAll) {
+		c.storeReturnMode(ReturnMode(mode))
+	}
+}
+
+// trackReturnMode 记录通过指令（如 ExecuteCommand("bkcmd=2")）修改的返回方式
+func (c *TjcDisplayClient) trackReturnMode(cmd string) {
+	name, value, ok := strings.Cut(cmd, "=")
+	if !ok || strings.TrimSpace(name) != "bkcmd" {
+		return
+	}
+
+	mode, err := strconv.Atoi(strings.TrimSpace(value))
+	if err == nil && mode >= int(ReturnNone) && mode <= int(ReturnAll) {
+		c.storeReturnMode(ReturnMode(mode))
+		c.explicitMode.Store(true)
+	}
+}
+
+// replyWait 等待指令返回的时间，expected 为 false 时没有返回即视为执行成功
+func (c *TjcDisplayClient) replyWait(cmd string) (wait time.Duration, expected bool) {
+	name, _, _ := strings.Cut(strings.TrimSpace(cmd), " ")
+	if strings.ContainsRune(name, '=') {
+		name = ""
+	}
+
+	if slices.Contains(queryInstructions, name) {
+		return c.timeout(), true
+	}
+
+	switch c.ReturnMode() {
+	case ReturnNone:
+		return 0, false
+	case ReturnFailure:
+		return min(failureWindow, c.timeout()), false
+	}
+
+	return c.timeout(), true
+}
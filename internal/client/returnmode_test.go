@@ -0,0 +1,99 @@
+package client
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/internal/simulator"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/consts"
+)
+
+// TestTjcDisplayClient_ReturnModeOnConnect 测试打开串口时读取设备的 bkcmd 而不修改它，
+// 调用方指定返回方式后重新打开串口时恢复该返回方式
+func TestTjcDisplayClient_ReturnModeOnConnect(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 1})
+	client := newSimulatedClient(t, device)
+
+	if err := client.Open(); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if device.ReturnMode() != int(ReturnSuccess) || client.ReturnMode() != ReturnSuccess {
+		t.Errorf("Expected bkcmd=1 to be kept, device %d, client %d", device.ReturnMode(), client.ReturnMode())
+	}
+	for _, cmd := range device.History() {
+		if strings.HasPrefix(cmd, "bkcmd=") {
+			t.Errorf("Expected no bkcmd assignment on open, got %q", cmd)
+		}
+	}
+
+	if err := client.SetReturnMode(ReturnNone); err != nil {
+		t.Fatalf("SetReturnMode failed: %v", err)
+	}
+
+	// 其他程序修改了 bkcmd，重新打开串口后恢复客户端的返回方式
+	client.Close()
+	other := device.Connect(115200)
+	other.Write([]byte("bkcmd=3\xFF\xFF\xFF"))
+	other.Close()
+
+	if err := client.Open(); err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	if device.ReturnMode() != int(ReturnNone) {
+		t.Errorf("Expected bkcmd=0 after reopen, got %d", device.ReturnMode())
+	}
+
+	if err := client.SetReturnMode(ReturnMode(4)); err == nil {
+		t.Error("Expected error for invalid return mode")
+	}
+}
+
+// TestTjcDisplayClient_ReturnFailure 测试 bkcmd=2 下成功的指令无需等待超时，失败仍返回 TjcError
+func TestTjcDisplayClient_ReturnFailure(t *testing.T) {
+	device := simulator.New(simulator.Config{})
+	device.AddPage("page1")
+	device.AddComponent(0, 1, "n0", map[string]any{"val": int32(7)})
+	client := newSimulatedClient(t, device)
+	client.Timeout = 2 * time.Second
+
+	if err := client.SetReturnMode(ReturnFailure); err != nil {
+		t.Fatalf("SetReturnMode failed: %v", err)
+	}
+	if device.ReturnMode() != int(ReturnFailure) {
+		t.Fatalf("Expected bkcmd=2, got %d", device.ReturnMode())
+	}
+
+	start := time.Now()
+	if err := client.JumpPage(1); err != nil {
+		t.Fatalf("JumpPage failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected successful write to return quickly, took %v", elapsed)
+	}
+	if device.Page() != 1 {
+		t.Errorf("Expected page 1, got %d", device.Page())
+	}
+
+	var tjcErr *TjcError
+	if err := client.JumpPage(9); !errors.As(err, &tjcErr) || tjcErr.Code != consts.CodeInvalidPageID {
+		t.Errorf("Expected invalid page error, got %v", err)
+	}
+
+	// 查询指令总是等待返回
+	if err := client.JumpPage(0); err != nil {
+		t.Fatalf("JumpPage failed: %v", err)
+	}
+	if val, err := client.GetNumber("n0.val"); err != nil || val != 7 {
+		t.Errorf("Expected n0.val 7, got %d, %v", val, err)
+	}
+
+	// 通过原始指令修改 bkcmd 也会被跟踪
+	if _, err := client.ExecuteCommand("bkcmd=0"); err != nil {
+		t.Fatalf("ExecuteCommand failed: %v", err)
+	}
+	if client.ReturnMode() != ReturnNone {
+		t.Errorf("Expected tracked bkcmd=0, got %d", client.ReturnMode())
+	}
+}
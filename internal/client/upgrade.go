@@ -0,0 +1,338 @@
+package client
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/models"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/tft"
+)
+
+// 升级协议
+const (
+	upgradeBlockSize      = 4096 // 每块数据大小，设备每收满一块应答一次
+	upgradeAck            = 0x05 // 设备已准备好接收下一块数据
+	upgradeSkip           = 0x08 // 设备要求跳到指定偏移继续发送（whmi-wris），后跟 4 字节小端偏移
+	defaultUpgradeBaud    = 921600
+	defaultUpgradeRetries = 3
+	defaultResyncWait     = 3 * time.Second // 等待设备放弃下载的时间
+)
+
+// UpgradeError 升级中断错误
+type UpgradeError struct {
+	Offset int64 // 设备已确认接收的字节数
+	Err    error
+}
+
+func (e *UpgradeError) Error() string {
+	return fmt.Sprintf("upgrade interrupted at byte %d: %v", e.Offset, e.Err)
+}
+
+func (e *UpgradeError) Unwrap() error {
+	return e.Err
+}
+
+// Upgrade 升级面板程序
+func (c *TjcDisplayClient) Upgrade(programPath string, baudRate int, progressCallback models.UpgradeProgressCallback) error {
+	return c.UpgradeWithOptions(programPath, &models.UpgradeOptions{
+		BaudRate: baudRate,
+		Progress: progressCallback,
+	})
+}
+
+// UpgradeWithOptions 升级面板程序
+// 升级前检查 TFT 文件的目标型号与设备是否匹配，opts.Force 为 true 时跳过。
+// 优先使用 whmi-wris 协议，设备可通过 0x08 应答跳过已存在的数据（包括上次中断前已写入的数据），
+// 设备不支持时回退到 whmi-wri。数据块应答超时后重新握手，从设备确认的偏移继续。
+func (c *TjcDisplayClient) UpgradeWithOptions(programPath string, opts *models.UpgradeOptions) error {
+	return c.UpgradeContext(context.Background(), programPath, opts)
+}
+
+// UpgradeContext 升级面板程序，context 取消或超时时中止升级
+// 中止后本地串口恢复连接波特率，返回的 *UpgradeError 记录设备已确认的偏移；
+// 设备仍停留在下载模式，重新执行升级（whmi-wris）可从该位置继续。
+func (c *TjcDisplayClient) UpgradeContext(ctx context.Context, programPath string, opts *models.UpgradeOptions) error {
+	if opts == nil {
+		opts = &models.UpgradeOptions{}
+	}
+
+	baudRate := opts.BaudRate
+	if baudRate == 0 {
+		baudRate = defaultUpgradeBaud
+	}
+
+	retries := opts.Retries
+	if retries == 0 {
+		retries = defaultUpgradeRetries
+	} else if retries < 0 {
+		retries = 0
+	}
+
+	resyncWait := opts.ResyncWait
+	if resyncWait <= 0 {
+		resyncWait = defaultResyncWait
+	}
+
+	err := c.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(programPath)
+	if err != nil {
+		return fmt.Errorf("failed to open program file: %w", err)
+	}
+	defer f.Close()
+
+	fileInfo, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to get file info: %w", err)
+	}
+
+	fileSize := fileInfo.Size()
+
+	// 升级前检查程序文件与设备是否匹配
+	if !opts.Force {
+		err = tft.Check(f, fileSize, func() (*models.DeviceInfo, error) {
+			return c.GetDeviceInfoContext(ctx)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// 升级期间直接读取串口，暂停后台读取协程
+	err = c.optLock.LockContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.optLock.Unlock()
+
+	c.stopReader()
+	defer c.startReader()
+
+	// 升级失败时也要恢复连接波特率
+	defer c.serialManager.SetBaudRate(c.BaudRate)
+
+	legacy, err := c.startUpgrade(ctx, fileSize, baudRate, opts.Legacy)
+	if err != nil {
+		return err
+	}
+
+	// 初始化进度信息
+	progress := newUpgradeProgress(fileSize, opts.Progress)
+	progress.report(0)
+
+	buf := make([]byte, upgradeBlockSize)
+	var offset int64
+	resyncs := 0
+	for offset < fileSize {
+		n, err := f.ReadAt(buf, offset)
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("failed to read program file: %w", err)
+		}
+		if n == 0 {
+			return fmt.Errorf("failed to read program file: unexpected end at byte %d", offset)
+		}
+
+		next, err := c.sendUpgradeBlock(ctx, buf[:n], offset)
+		if errors.Is(err, errUpgradeAckTimeout) && resyncs < retries {
+			resyncs++
+			legacy, err = c.resyncUpgrade(ctx, fileSize, baudRate, legacy, resyncWait)
+			if err == nil {
+				// 重新握手后从头发送，whmi-wris 在第一块之后由 0x08 应答跳到设备确认的偏移
+				offset = 0
+				continue
+			}
+		}
+		if err != nil {
+			return &UpgradeError{Offset: offset, Err: err}
+		}
+		if next > fileSize {
+			return &UpgradeError{Offset: offset, Err: fmt.Errorf("skip offset %d exceeds file size %d", next, fileSize)}
+		}
+
+		offset = next
+		progress.report(offset)
+	}
+
+	// 重连串口
+	return c.serialManager.SetBaudRate(c.BaudRate)
+}
+
+// startUpgrade 发送升级指令并切换到下载波特率，等待设备准备就绪，返回是否使用了 whmi-wri
+func (c *TjcDisplayClient) startUpgrade(ctx context.Context, fileSize int64, baudRate int, legacy bool) (bool, error) {
+	if !legacy {
+		err := c.sendUpgradeCommand(ctx, "whmi-wris", fileSize, baudRate)
+		if err == nil {
+			return false, nil
+		}
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+
+		// 设备不支持 whmi-wris，恢复波特率并清理设备返回的错误后改用 whmi-wri
+		err = c.serialManager.SetBaudRate(c.BaudRate)
+		if err != nil {
+			return false, err
+		}
+		_, _ = c.serialManager.ReadWithTimeout(100 * time.Millisecond)
+	}
+
+	return true, c.sendUpgradeCommand(ctx, "whmi-wri", fileSize, baudRate)
+}
+
+// resyncUpgrade 数据块应答超时后重新握手
+//
+// 此时无法知道设备收到了多少数据：数据块可能只到达了一部分，应答也可能只是迟到，直接重发会让设备的
+// 字节计数超前于主机，写入错位的程序。因此等待设备因长时间没有数据而放弃本次下载，清理迟到的应答后
+// 以连接波特率重新发送升级指令：whmi-wris 由设备通过 0x08 应答告知已确认的偏移，whmi-wri 从头开始
+func (c *TjcDisplayClient) resyncUpgrade(ctx context.Context, fileSize int64, baudRate int, legacy bool, wait time.Duration) (bool, error) {
+	select {
+	case <-time.After(wait):
+	case <-ctx.Done():
+		return legacy, ctx.Err()
+	}
+
+	err := c.serialManager.SetBaudRate(c.BaudRate)
+	if err != nil {
+		return legacy, err
+	}
+	_, _ = c.serialManager.ReadWithTimeout(100 * time.Millisecond)
+
+	return c.startUpgrade(ctx, fileSize, baudRate, legacy)
+}
+
+// sendUpgradeCommand 发送 whmi-wri/whmi-wris 指令，设备以 0x05 表示准备就绪
+func (c *TjcDisplayClient) sendUpgradeCommand(ctx context.Context, command string, fileSize int64, baudRate int) error {
+	// 使用当前连接的波特率发送指令
+	cmd := []byte(fmt.Sprintf("%s %d,%d,0", command, fileSize, baudRate))
+	cmd = append(cmd, EndSymbol...)
+	err := c.serialManager.WriteContext(ctx, cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initiate upgrade: %w", err)
+	}
+
+	// 等待350ms，确保设备已准备好
+	select {
+	case <-time.After(350 * time.Millisecond):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	// 切换到下载波特率
+	err = c.serialManager.SetBaudRate(baudRate)
+	if err != nil {
+		return fmt.Errorf("failed to set new baud rate: %w", err)
+	}
+
+	resp, err := c.serialManager.ReadExactlyContext(ctx, 1)
+	if err != nil {
+		return fmt.Errorf("failed to read upgrade response: %w", err)
+	}
+
+	if resp[0] != upgradeAck {
+		return fmt.Errorf("unexpected upgrade response: got 0x%02X, expected 0x%02X", resp[0], upgradeAck)
+	}
+
+	return nil
+}
+
+// sendUpgradeBlock 发送一块数据并等待应答，返回下一块的偏移
+func (c *TjcDisplayClient) sendUpgradeBlock(ctx context.Context, block []byte, offset int64) (int64, error) {
+	err := c.serialManager.WriteContext(ctx, block)
+	if err != nil {
+		return 0, fmt.Errorf("failed to write program data: %w", err)
+	}
+
+	return c.readUpgradeAck(ctx, offset+int64(len(block)))
+}
+
+var errUpgradeAckTimeout = errors.New("timeout waiting for upgrade response")
+
+// readUpgradeAck 读取数据块应答：0x05 继续发送下一块，0x08 跳转到指定偏移
+func (c *TjcDisplayClient) readUpgradeAck(ctx context.Context, next int64) (int64, error) {
+	resp, err := c.serialManager.ReadExactlyContext(ctx, 1)
+	if err != nil {
+		if ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+		if len(resp) == 0 {
+			return 0, errUpgradeAckTimeout
+		}
+		return 0, fmt.Errorf("failed to read upgrade response: %w", err)
+	}
+
+	switch resp[0] {
+	case upgradeAck:
+		return next, nil
+	case upgradeSkip:
+		data, err := c.serialManager.ReadExactlyContext(ctx, 4)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read upgrade skip offset: %w", err)
+		}
+
+		// 偏移为 0 表示无需跳过
+		skip := int64(binary.LittleEndian.Uint32(data))
+		if skip > 0 {
+			return skip, nil
+		}
+		return next, nil
+	default:
+		return 0, fmt.Errorf("unexpected upgrade response: got 0x%02X, expected 0x%02X", resp[0], upgradeAck)
+	}
+}
+
+// upgradeProgress 计算并上报升级进度
+type upgradeProgress struct {
+	total    int64
+	start    time.Time
+	callback models.UpgradeProgressCallback
+}
+
+func newUpgradeProgress(total int64, callback models.UpgradeProgressCallback) *upgradeProgress {
+	return &upgradeProgress{
+		total:    total,
+		start:    time.Now(),
+		callback: callback,
+	}
+}
+
+func (p *upgradeProgress) report(current int64) {
+	if p.callback == nil {
+		return
+	}
+
+	elapsed := time.Since(p.start)
+	if current == 0 {
+		elapsed = 0
+	}
+
+	var percentage float64
+	if p.total > 0 {
+		percentage = float64(current) / float64(p.total) * 100
+	}
+
+	var speed int64
+	var remaining time.Duration
+	if elapsed.Seconds() > 0 {
+		speed = int64(float64(current) / elapsed.Seconds())
+		if speed > 0 && current < p.total {
+			remaining = time.Duration(float64(p.total-current)/float64(speed)) * time.Second
+		}
+	}
+
+	p.callback(&models.UpgradeProgress{
+		Current:    current,
+		Total:      p.total,
+		Percentage: percentage,
+		Speed:      speed,
+		Elapsed:    elapsed,
+		Remaining:  remaining,
+	})
+}
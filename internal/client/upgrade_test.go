@@ -0,0 +1,269 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/internal/serial"
+	"github.com/blue-cloud-net/tjc-serial-display/internal/simulator"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/models"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/tft"
+)
+
+// buildProgram 生成 size 字节的程序数据，文件开头写入目标型号
+func buildProgram(model string, size int) []byte {
+	program := make([]byte, size)
+	for i := range program {
+		program[i] = byte(i * 7)
+	}
+	copy(program[16:], model)
+
+	return program
+}
+
+// writeProgram 生成适用于模拟设备的程序文件，size 为文件总大小
+func writeProgram(t *testing.T, size int) (string, []byte) {
+	t.Helper()
+
+	program := buildProgram("TJC4024T032_011R", size)
+	path := filepath.Join(t.TempDir(), "program.tft")
+	if err := os.WriteFile(path, program, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path, program
+}
+
+// hasInstruction 判断设备是否收到过以指定前缀开头的指令
+func hasInstruction(device *simulator.Device, prefix string) bool {
+	for _, instruction := range device.History() {
+		if strings.HasPrefix(instruction, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TestUpgrade_Wris 测试优先使用 whmi-wris 协议
+func TestUpgrade_Wris(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	client := newSimulatedClient(t, device)
+	path, program := writeProgram(t, 9000)
+
+	if err := client.UpgradeWithOptions(path, nil); err != nil {
+		t.Fatalf("Upgrade failed: %v", err)
+	}
+
+	if !hasInstruction(device, "whmi-wris 9000,921600,0") {
+		t.Errorf("Expected whmi-wris instruction, got %v", device.History())
+	}
+	if !bytes.Equal(device.UpgradeData(), program) {
+		t.Error("Expected device to receive the whole program")
+	}
+}
+
+// TestUpgrade_LegacyFallback 测试设备不支持 whmi-wris 时回退到 whmi-wri
+func TestUpgrade_LegacyFallback(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3, Legacy: true})
+	client := newSimulatedClient(t, device)
+	path, program := writeProgram(t, 5000)
+
+	if err := client.UpgradeWithOptions(path, nil); err != nil {
+		t.Fatalf("Upgrade failed: %v", err)
+	}
+
+	if !hasInstruction(device, "whmi-wri 5000,921600,0") {
+		t.Errorf("Expected fallback to whmi-wri, got %v", device.History())
+	}
+	if !bytes.Equal(device.UpgradeData(), program) {
+		t.Error("Expected device to receive the whole program")
+	}
+	if _, err := client.GetDeviceInfo(); err != nil {
+		t.Errorf("GetDeviceInfo after upgrade failed: %v", err)
+	}
+}
+
+// TestUpgrade_Resync 测试数据块应答超时后重新握手，从设备确认的偏移继续而不是重发该块
+func TestUpgrade_Resync(t *testing.T) {
+	tests := []struct {
+		name   string
+		legacy bool
+		fault  func(device *simulator.Device)
+	}{
+		{"DroppedBlock", false, func(device *simulator.Device) { device.DropUpgradeBlock(4096) }},
+		{"PartialBlock", false, func(device *simulator.Device) { device.TruncateUpgradeBlock(4096, 1000) }},
+		{"LateAck", false, func(device *simulator.Device) { device.DelayUpgradeAck(4096, 300*time.Millisecond) }},
+		{"LegacyPartialBlock", true, func(device *simulator.Device) { device.TruncateUpgradeBlock(4096, 1000) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device := simulator.New(simulator.Config{ReturnMode: 3, Legacy: tt.legacy, UpgradeTimeout: 500 * time.Millisecond})
+			tt.fault(device)
+			client := newSimulatedClient(t, device)
+			path, program := writeProgram(t, 12000)
+
+			err := client.UpgradeWithOptions(path, &models.UpgradeOptions{Legacy: tt.legacy, ResyncWait: 700 * time.Millisecond})
+			if err != nil {
+				t.Fatalf("Upgrade failed: %v", err)
+			}
+
+			if !bytes.Equal(device.UpgradeData(), program) {
+				t.Error("Expected device to receive the whole program after resync")
+			}
+		})
+	}
+}
+
+// TestUpgrade_Resume 测试中断后重新升级时跳过已写入的数据
+func TestUpgrade_Resume(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	device.InterruptUpgrade(10000)
+	client := newSimulatedClient(t, device)
+	path, program := writeProgram(t, 20000)
+
+	err := client.UpgradeWithOptions(path, &models.UpgradeOptions{Retries: -1})
+	var upgradeErr *UpgradeError
+	if !errors.As(err, &upgradeErr) {
+		t.Fatalf("Expected UpgradeError, got %v", err)
+	}
+	if upgradeErr.Offset != 8192 {
+		t.Errorf("Expected interruption at byte 8192, got %d", upgradeErr.Offset)
+	}
+
+	var reports []int64
+	err = client.UpgradeWithOptions(path, &models.UpgradeOptions{
+		Progress: func(progress *models.UpgradeProgress) {
+			reports = append(reports, progress.Current)
+		},
+	})
+	if err != nil {
+		t.Fatalf("Resumed upgrade failed: %v", err)
+	}
+
+	// 第一块之后设备要求直接跳到 8192
+	if len(reports) < 2 || reports[1] != 8192 {
+		t.Errorf("Expected progress to jump to 8192 after the first block, got %v", reports)
+	}
+	if reports[len(reports)-1] != 20000 {
+		t.Errorf("Expected final progress 20000, got %v", reports)
+	}
+	if !bytes.Equal(device.UpgradeData(), program) {
+		t.Error("Expected device to hold the whole program after resume")
+	}
+}
+
+// TestUpgrade_Preflight 测试升级前的程序文件型号检查
+func TestUpgrade_Preflight(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	client := newSimulatedClient(t, device)
+
+	wrongModel := buildProgram("TJC8048X570_011C", 5000)
+	path := filepath.Join(t.TempDir(), "wrong.tft")
+	if err := os.WriteFile(path, wrongModel, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := client.UpgradeWithOptions(path, nil)
+	if !errors.Is(err, tft.ErrModelMismatch) {
+		t.Fatalf("Expected ErrModelMismatch, got %v", err)
+	}
+	if hasInstruction(device, "whmi-wri") {
+		t.Error("Expected no upgrade instruction for mismatched file")
+	}
+
+	// 无法识别的文件同样拒绝
+	unknown := bytes.Repeat([]byte{0x5A}, 5000)
+	if err := os.WriteFile(path, unknown, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.UpgradeWithOptions(path, nil); !errors.Is(err, tft.ErrInvalidHeader) {
+		t.Errorf("Expected ErrInvalidHeader, got %v", err)
+	}
+
+	// 强制升级时跳过检查
+	if err := client.UpgradeWithOptions(path, &models.UpgradeOptions{Force: true}); err != nil {
+		t.Fatalf("Forced upgrade failed: %v", err)
+	}
+	if !bytes.Equal(device.UpgradeData(), unknown) {
+		t.Error("Expected device to receive the forced program")
+	}
+}
+
+// TestUpgrade_Context 测试升级过程中取消 context
+func TestUpgrade_Context(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	client := newSimulatedClient(t, device)
+	path, _ := writeProgram(t, 20000)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := client.UpgradeContext(ctx, path, &models.UpgradeOptions{
+		Progress: func(progress *models.UpgradeProgress) {
+			if progress.Current >= 4096 {
+				cancel()
+			}
+		},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+
+	var upgradeErr *UpgradeError
+	if !errors.As(err, &upgradeErr) || upgradeErr.Offset != 4096 {
+		t.Errorf("Expected UpgradeError at byte 4096, got %v", err)
+	}
+	if len(device.UpgradeData()) > 4096 {
+		t.Errorf("Expected no data after cancellation, device got %d bytes", len(device.UpgradeData()))
+	}
+
+	// 本地串口恢复连接波特率
+	if client.serialManager.BaudRate != 115200 {
+		t.Errorf("Expected local baud rate restored to 115200, got %d", client.serialManager.BaudRate)
+	}
+}
+
+// TestUpgrade_RFC2217 测试通过 serve-port 共享的串口升级，下载波特率经 RFC 2217 下发到设备
+func TestUpgrade_RFC2217(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+
+	spm := &serial.SerialPortManager{BaudRate: 115200, Timeout: 50 * time.Millisecond, Opener: device.Opener()}
+	if err := spm.Open(); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer spm.Close()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	server := &serial.PortServer{Manager: spm, RFC2217: true}
+	go server.Serve(listener)
+	defer server.Close()
+
+	client := &TjcDisplayClient{
+		PortName: "rfc2217://" + listener.Addr().String(),
+		BaudRate: 115200,
+		Timeout:  500 * time.Millisecond,
+	}
+	defer client.Close()
+
+	// 模拟设备只接收波特率一致的数据，升级成功说明下载波特率已切换到共享的串口
+	path, program := writeProgram(t, 9000)
+	if err := client.UpgradeWithOptions(path, nil); err != nil {
+		t.Fatalf("Upgrade failed: %v", err)
+	}
+
+	if !bytes.Equal(device.UpgradeData(), program) {
+		t.Error("Expected device to receive the whole program")
+	}
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/consts"
+)
+
+// GetNumber 获取目标的数值，如 n0.val、sys0
+func (c *TjcDisplayClient) GetNumber(target string) (int32, error) {
+	resp, err := c.get(target)
+	if err != nil {
+		return 0, err
+	}
+
+	return decodeNumber(resp)
+}
+
+// GetString 获取目标的字符串，如 t0.txt
+func (c *TjcDisplayClient) GetString(target string) (string, error) {
+	resp, err := c.get(target)
+	if err != nil {
+		return "", err
+	}
+
+	return decodeString(resp)
+}
+
+// Get 获取目标的值，字符串返回 string，数值返回 int32
+func (c *TjcDisplayClient) Get(target string) (any, error) {
+	resp, err := c.get(target)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code == consts.CodeNumberData {
+		return decodeNumber(resp)
+	}
+
+	return decodeString(resp)
+}
+
+// get 发送 get 指令，设备以 0x70（字符串）或 0x71（数值）返回
+func (c *TjcDisplayClient) get(target string) (*Response, error) {
+	err := c.connect(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return c.sendCommandAndWaitResponse(context.Background(), fmt.Sprintf("get %s", target), false)
+}
+
+// decodeNumber 解析 0x71 数值返回：4 字节小端有符号整数
+func decodeNumber(resp *Response) (int32, error) {
+	if resp.Code != consts.CodeNumberData {
+		return 0, fmt.Errorf("unexpected response code 0x%02X, expected number data 0x%02X", resp.Code, consts.CodeNumberData)
+	}
+
+	if len(resp.Data) != 4 {
+		return 0, fmt.Errorf("invalid number data length: %d", len(resp.Data))
+	}
+
+	return int32(binary.LittleEndian.Uint32(resp.Data)), nil
+}
+
+// decodeString 解析 0x70 字符串返回
+func decodeString(resp *Response) (string, error) {
+	if resp.Code != consts.CodeStringData {
+		return "", fmt.Errorf("unexpected response code 0x%02X, expected string data 0x%02X", resp.Code, consts.CodeStringData)
+	}
+
+	return string(resp.Data), nil
+}
+
+// Value 解析 get 返回的值，字符串返回 string，数值返回 int32
+func (r *Response) Value() (any, error) {
+	if r.Code == consts.CodeNumberData {
+		return decodeNumber(r)
+	}
+
+	return decodeString(r)
+}
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/blue-cloud-net/tjc-serial-display/internal/simulator"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/consts"
+)
+
+// TestDecodeNumber 测试 0x71 数值返回解析
+func TestDecodeNumber(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     []byte
+		expected int32
+	}{
+		{"Zero", []byte{0x00, 0x00, 0x00, 0x00}, 0},
+		{"Positive", []byte{0x39, 0x30, 0x00, 0x00}, 12345},
+		{"Negative", []byte{0xFF, 0xFF, 0xFF, 0xFF}, -1},
+		{"Min", []byte{0x00, 0x00, 0x00, 0x80}, -2147483648},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := decodeNumber(&Response{Code: consts.CodeNumberData, Data: tc.data})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if value != tc.expected {
+				t.Errorf("Expected %d, got %d", tc.expected, value)
+			}
+		})
+	}
+
+	if _, err := decodeNumber(&Response{Code: consts.CodeNumberData, Data: []byte{0x01}}); err == nil {
+		t.Error("Expected error for short number data")
+	}
+	if _, err := decodeNumber(&Response{Code: consts.CodeStringData, Data: []byte("abcd")}); err == nil {
+		t.Error("Expected error for string data")
+	}
+}
+
+// TestFrameLength 测试数值返回中包含 0xFF 时的分帧
+func TestFrameLength(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     []byte
+		expected int
+	}{
+		{"Success", []byte{0x01, 0xFF, 0xFF, 0xFF, 0x66}, 4},
+		{"NumberWithFF", []byte{0x71, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}, 8},
+		{"IncompleteNumber", []byte{0x71, 0xFF, 0xFF, 0xFF, 0xFF}, -1},
+		{"NoTerminator", []byte("hello"), -1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if n := frameLength(tc.data); n != tc.expected {
+				t.Errorf("Expected %d, got %d", tc.expected, n)
+			}
+		})
+	}
+}
+
+// TestTjcDisplayClient_GetValues 使用模拟设备测试 get 指令读取数值和字符串
+func TestTjcDisplayClient_GetValues(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	device.AddComponent(0, 1, "t0", map[string]any{"txt": "你好"})
+	device.AddComponent(0, 2, "n0", map[string]any{"val": int32(-1)})
+	client := newSimulatedClient(t, device)
+
+	number, err := client.GetNumber("n0.val")
+	if err != nil {
+		t.Fatalf("GetNumber failed: %v", err)
+	}
+	if number != -1 {
+		t.Errorf("Expected -1, got %d", number)
+	}
+
+	text, err := client.GetString("t0.txt")
+	if err != nil {
+		t.Fatalf("GetString failed: %v", err)
+	}
+	if text != "你好" {
+		t.Errorf("Expected 你好, got %q", text)
+	}
+
+	// 类型不匹配时返回错误
+	if _, err := client.GetNumber("t0.txt"); err == nil {
+		t.Error("Expected error when reading string as number")
+	}
+
+	// 变量不存在时返回设备错误码
+	_, err = client.GetString("t9.txt")
+	tjcErr, ok := err.(*TjcError)
+	if !ok || tjcErr.Code != consts.CodeInvalidVariableName {
+		t.Errorf("Expected invalid variable error, got %v", err)
+	}
+
+	value, err := client.Get("n0.val")
+	if err != nil || value != int32(-1) {
+		t.Errorf("Expected Get to return int32(-1), got %v, %v", value, err)
+	}
+
+	printed, err := client.Prints("t0.txt")
+	if err != nil {
+		t.Fatalf("Prints failed: %v", err)
+	}
+	if printed != "你好" {
+		t.Errorf("Expected print output 你好, got %q", printed)
+	}
+	// 以事件码开头的输出（h = 0x68）不能被当作事件
+	printed, err = client.Prints(`"hello"`)
+	if err != nil {
+		t.Fatalf("Prints failed: %v", err)
+	}
+	if printed != "hello" {
+		t.Errorf("Expected print output hello, got %q", printed)
+	}
+}
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/consts"
+)
+
+// 曲线控件最多 4 个通道（0-3）
+const maxWaveformChannel = 3
+
+// addt 单次透传的最大字节数，超过时分多次透传
+const maxTransparentSize = 1024
+
+// WaveformAppend 通过 addt 透传向曲线控件的通道追加数据点，每个字节为一个点（0-255，对应曲线高度像素）
+func (c *TjcDisplayClient) WaveformAppend(componentID, channel int, samples []byte) error {
+	return c.WaveformAppendContext(context.Background(), componentID, channel, samples)
+}
+
+// WaveformAppendContext 通过 addt 透传向曲线控件的通道追加数据点，context 取消时立即返回
+//
+// 超过单次透传上限的数据分多次发送，每次都等待设备就绪（0xFE）和透传完成（0xFD）
+func (c *TjcDisplayClient) WaveformAppendContext(ctx context.Context, componentID, channel int, samples []byte) error {
+	if componentID < 0 || componentID > 255 {
+		return fmt.Errorf("invalid component ID %d, expected 0-255", componentID)
+	}
+	if channel < 0 || channel > maxWaveformChannel {
+		return fmt.Errorf("invalid channel %d, expected 0-%d", channel, maxWaveformChannel)
+	}
+	if len(samples) == 0 {
+		return nil
+	}
+
+	err := c.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	for len(samples) > 0 {
+		n := min(len(samples), maxTransparentSize)
+		cmd := fmt.Sprintf("addt %d,%d,%d", componentID, channel, n)
+
+		err = c.sendTransparent(ctx, cmd, samples[:n])
+		if err != nil {
+			return err
+		}
+
+		samples = samples[n:]
+	}
+
+	return nil
+}
+
+// sendTransparent 发送透传指令，设备就绪后发送原始数据并等待透传完成
+// 透传期间设备不解析指令，整个过程持有 optLock
+func (c *TjcDisplayClient) sendTransparent(ctx context.Context, cmd string, data []byte) error {
+	err := c.checkAwake(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	err = c.optLock.LockContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.optLock.Unlock()
+
+	c.discardResponses()
+
+	c.pending.Store(true)
+	defer c.pending.Store(false)
+
+	err = c.writeAndFlush(ctx, append([]byte(cmd), EndSymbol...))
+	if err != nil {
+		return err
+	}
+
+	err = c.waitTransparent(ctx, consts.CodeTransparentReady, c.timeout())
+	if err != nil {
+		return c.sleepError(cmd, err)
+	}
+
+	err = c.writeAndFlush(ctx, data)
+	if err != nil {
+		return err
+	}
+
+	return c.waitTransparent(ctx, consts.CodeTransparentDone, c.timeout()+c.transferTime(len(data)))
+}
+
+func (c *TjcDisplayClient) writeAndFlush(ctx context.Context, data []byte) error {
+	err := c.serialManager.WriteContext(ctx, data)
+	if err != nil {
+		return err
+	}
+
+	return c.serialManager.Flush()
+}
+
+// waitTransparent 等待透传应答，设备返回的错误码（如曲线ID无效）转为 *TjcError
+func (c *TjcDisplayClient) waitTransparent(ctx context.Context, code byte, wait time.Duration) error {
+	deadline := time.Now().Add(wait)
+	for {
+		frame, err := c.waitResponse(ctx, time.Until(deadline))
+		if err != nil {
+			return fmt.Errorf("waiting for transparent response 0x%02X: %w", code, err)
+		}
+
+		resp, err := parseResponse(frame)
+		if err != nil {
+			return fmt.Errorf("unexpected transparent response % X", frame)
+		}
+		if respErr := resp.toError(); respErr != nil {
+			return respErr
+		}
+
+		// bkcmd 为 1 或 3 时可能先返回指令成功
+		if resp.Type == ResponseTypeSuccess {
+			continue
+		}
+		if resp.Code != code {
+			return fmt.Errorf("unexpected transparent response 0x%02X, expected 0x%02X", resp.Code, code)
+		}
+
+		return nil
+	}
+}
+
+// transferTime 按当前波特率估算发送 n 字节所需的时间（每字节 10 位）
+func (c *TjcDisplayClient) transferTime(n int) time.Duration {
+	if c.BaudRate <= 0 {
+		return 0
+	}
+
+	return time.Duration(n) * 10 * time.Second / time.Duration(c.BaudRate)
+}
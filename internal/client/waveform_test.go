@@ -0,0 +1,65 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/blue-cloud-net/tjc-serial-display/internal/simulator"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/consts"
+)
+
+// TestTjcDisplayClient_WaveformAppend 测试 addt 透传，超过单次上限时分块发送
+func TestTjcDisplayClient_WaveformAppend(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	device.AddWaveform(0, 1, "s0", 2)
+	client := newSimulatedClient(t, device)
+
+	// 数据点中包含结束符，不影响透传
+	samples := make([]byte, 2500)
+	for i := range samples {
+		samples[i] = byte(i)
+	}
+	samples[10], samples[11], samples[12] = 0xFF, 0xFF, 0xFF
+
+	if err := client.WaveformAppend(1, 1, samples); err != nil {
+		t.Fatalf("WaveformAppend failed: %v", err)
+	}
+	if !bytes.Equal(device.Waveform(0, "s0", 1), samples) {
+		t.Errorf("Expected %d samples on channel 1, got %d", len(samples), len(device.Waveform(0, "s0", 1)))
+	}
+
+	var chunks []string
+	for _, cmd := range device.History() {
+		if strings.HasPrefix(cmd, "addt ") {
+			chunks = append(chunks, cmd)
+		}
+	}
+	expected := []string{"addt 1,1,1024", "addt 1,1,1024", "addt 1,1,452"}
+	if strings.Join(chunks, ";") != strings.Join(expected, ";") {
+		t.Errorf("Expected %v, got %v", expected, chunks)
+	}
+
+	// 透传结束后可以继续发送普通指令
+	if _, err := client.GetPage(); err != nil {
+		t.Errorf("GetPage after addt failed: %v", err)
+	}
+}
+
+// TestTjcDisplayClient_WaveformAppend_InvalidCurve 测试通道号无效时返回设备错误码
+func TestTjcDisplayClient_WaveformAppend_InvalidCurve(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	device.AddWaveform(0, 1, "s0", 1)
+	client := newSimulatedClient(t, device)
+
+	var tjcErr *TjcError
+	err := client.WaveformAppend(1, 2, []byte{1, 2, 3})
+	if !errors.As(err, &tjcErr) || tjcErr.Code != consts.CodeInvalidCurveID {
+		t.Errorf("Expected invalid curve error, got %v", err)
+	}
+
+	if err := client.WaveformAppend(1, 4, []byte{1}); err == nil {
+		t.Error("Expected error for channel 4")
+	}
+}
@@ -0,0 +1,79 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/internal/apimodel"
+)
+
+const (
+	sseHeartbeat    = 15 * time.Second
+	eventBufferSize = 64
+)
+
+// handleEvents 以 SSE 推送设备事件（触摸、页面、睡眠唤醒等），事件名为事件类型，如 touch
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := startSSE(w)
+	if !ok {
+		return
+	}
+
+	events, unsubscribe := s.Client.Events(eventBufferSize)
+	defer unsubscribe()
+
+	heartbeat := time.NewTicker(sseHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event := <-events:
+			if writeSSE(w, event.Type.String(), apimodel.NewEvent(event)) != nil {
+				return
+			}
+			flusher.Flush()
+		case <-heartbeat.C:
+			if writeSSEComment(w) != nil {
+				return
+			}
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func startSSE(w http.ResponseWriter) (http.Flusher, bool) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeErrorMessage(w, http.StatusInternalServerError, "streaming is not supported")
+		return nil, false
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	return flusher, true
+}
+
+func writeSSE(w io.Writer, name string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, data)
+	return err
+}
+
+// writeSSEComment 发送注释行保持连接，避免被代理判定为空闲
+func writeSSEComment(w io.Writer) error {
+	_, err := io.WriteString(w, ": ping\n\n")
+	return err
+}
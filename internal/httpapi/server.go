@@ -0,0 +1,326 @@
+package httpapi
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/blue-cloud-net/tjc-serial-display/internal/apimodel"
+	"github.com/blue-cloud-net/tjc-serial-display/internal/client"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/components"
+)
+
+// 请求体大小上限，上传的程序文件除外
+const maxBodySize = 1 << 20
+
+var errUpgrading = errors.New("upgrade in progress")
+
+// Server 通过 HTTP/JSON 控制显示屏（tjc serve），所有请求共用同一个长连接的客户端
+//
+//	GET  /api/device                        设备信息
+//	GET  /api/page                          当前页面
+//	PUT  /api/page                          跳转页面 {"page": 1}
+//	GET  /api/components/{name}/{attr}      读取控件属性
+//	PUT  /api/components/{name}/{attr}      设置控件属性 {"value": "text"} 或 {"value": 12}
+//	POST /api/instruction                   执行原始指令 {"instruction": "vis b0,0"}
+//	POST /api/upgrade                       上传 TFT 文件并开始升级（multipart，字段 file、force）
+//	GET  /api/upgrade                       升级状态
+//	GET  /api/upgrade/events                升级进度（SSE）
+//	GET  /api/events                        设备事件（SSE）
+type Server struct {
+	Client  *client.TjcDisplayClient
+	TempDir string // 上传的程序文件存放目录，为空时使用系统临时目录
+
+	mu       sync.Mutex
+	upgrade  *upgradeStatus                   // 最近一次升级的状态
+	watchers map[chan *upgradeStatus]struct{} // 升级进度订阅者
+}
+
+// Handler 返回 API 路由
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/device", s.handleDevice)
+	mux.HandleFunc("GET /api/page", s.handleGetPage)
+	mux.HandleFunc("PUT /api/page", s.handlePutPage)
+	mux.HandleFunc("GET /api/components/{name}/{attr}", s.handleGetAttribute)
+	mux.HandleFunc("PUT /api/components/{name}/{attr}", s.handlePutAttribute)
+	mux.HandleFunc("POST /api/instruction", s.handleInstruction)
+	mux.HandleFunc("POST /api/upgrade", s.handleUpgrade)
+	mux.HandleFunc("GET /api/upgrade", s.handleUpgradeStatus)
+	mux.HandleFunc("GET /api/upgrade/events", s.handleUpgradeEvents)
+	mux.HandleFunc("GET /api/events", s.handleEvents)
+
+	return mux
+}
+
+func (s *Server) handleDevice(w http.ResponseWriter, r *http.Request) {
+	if s.rejectDuringUpgrade(w) {
+		return
+	}
+
+	info, err := s.Client.GetDeviceInfoContext(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, apimodel.NewDeviceInfo(info))
+}
+
+type pageBody struct {
+	Page *int `json:"page"`
+}
+
+func (s *Server) handleGetPage(w http.ResponseWriter, r *http.Request) {
+	if s.rejectDuringUpgrade(w) {
+		return
+	}
+
+	page, err := s.Client.GetPage()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, pageBody{Page: &page})
+}
+
+func (s *Server) handlePutPage(w http.ResponseWriter, r *http.Request) {
+	var body pageBody
+	if !readJSON(w, r, &body) {
+		return
+	}
+	if body.Page == nil || *body.Page < 0 {
+		writeErrorMessage(w, http.StatusBadRequest, "page is required and must not be negative")
+		return
+	}
+
+	if s.rejectDuringUpgrade(w) {
+		return
+	}
+
+	err := s.Client.JumpPage(*body.Page)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, body)
+}
+
+// attributeBody 控件属性，value 为字符串或整数
+type attributeBody struct {
+	Component string `json:"component"`
+	Attribute string `json:"attribute"`
+	Value     any    `json:"value"`
+}
+
+func (s *Server) handleGetAttribute(w http.ResponseWriter, r *http.Request) {
+	name, attr, ok := componentPath(w, r)
+	if !ok {
+		return
+	}
+
+	if s.rejectDuringUpgrade(w) {
+		return
+	}
+
+	value, err := s.Client.Get(name + "." + attr)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, attributeBody{Component: name, Attribute: attr, Value: value})
+}
+
+func (s *Server) handlePutAttribute(w http.ResponseWriter, r *http.Request) {
+	name, attr, ok := componentPath(w, r)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		Value json.RawMessage `json:"value"`
+	}
+	if !readJSON(w, r, &body) {
+		return
+	}
+
+	var text string
+	var number int32
+	isText := json.Unmarshal(body.Value, &text) == nil
+	if !isText && json.Unmarshal(body.Value, &number) != nil {
+		writeErrorMessage(w, http.StatusBadRequest, "value must be a string or a 32-bit integer")
+		return
+	}
+
+	if s.rejectDuringUpgrade(w) {
+		return
+	}
+
+	// 借助控件句柄完成名称校验和字符串转义
+	component := &s.Client.Text(name).Component
+	var err error
+	var value any
+	if isText {
+		err = component.SetString(attr, text)
+		value = text
+	} else {
+		err = component.SetNumber(attr, number)
+		value = number
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, attributeBody{Component: name, Attribute: attr, Value: value})
+}
+
+// componentPath 读取并校验路径中的控件名称和属性名
+func componentPath(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+	name := r.PathValue("name")
+	attr := r.PathValue("attr")
+
+	if !apimodel.IsIdentifier(attr) {
+		writeErrorMessage(w, http.StatusBadRequest, fmt.Sprintf("invalid attribute name %q", attr))
+		return "", "", false
+	}
+	if components.ValidateName(name) != nil {
+		writeErrorMessage(w, http.StatusBadRequest, fmt.Sprintf("invalid component name %q", name))
+		return "", "", false
+	}
+
+	return name, attr, true
+}
+
+// instructionResponse 原始指令的执行结果
+type instructionResponse struct {
+	Type  string `json:"type"` // success、data、event、raw、none
+	Code  string `json:"code,omitempty"`
+	Value any    `json:"value,omitempty"` // 数据返回的值，或事件内容（如 sendme 返回的页面）
+	Hex   string `json:"hex,omitempty"`
+}
+
+func (s *Server) handleInstruction(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Instruction string `json:"instruction"`
+	}
+	if !readJSON(w, r, &body) {
+		return
+	}
+
+	instruction := strings.TrimSpace(body.Instruction)
+	if instruction == "" {
+		writeErrorMessage(w, http.StatusBadRequest, "instruction is required")
+		return
+	}
+
+	if s.rejectDuringUpgrade(w) {
+		return
+	}
+
+	result, err := s.Client.ExecuteCommandContext(r.Context(), instruction)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if len(result) == 0 {
+		writeJSON(w, http.StatusOK, instructionResponse{Type: "none"})
+		return
+	}
+
+	out := instructionResponse{Type: "raw", Hex: strings.ToUpper(hex.EncodeToString(result))}
+
+	resp, err := client.ParseResponse(result)
+	if err != nil {
+		writeJSON(w, http.StatusOK, out)
+		return
+	}
+
+	out.Code = fmt.Sprintf("0x%02X", resp.Code)
+	switch resp.Type {
+	case client.ResponseTypeError:
+		writeError(w, resp.Err())
+		return
+	case client.ResponseTypeSuccess:
+		out.Type = "success"
+	case client.ResponseTypeEvent:
+		if event, ok := resp.Event(); ok {
+			out.Type = "event"
+			out.Value = apimodel.NewEvent(event)
+		}
+	case client.ResponseTypeData:
+		if value, err := resp.Value(); err == nil {
+			out.Type = "data"
+			out.Value = value
+		}
+	}
+
+	writeJSON(w, http.StatusOK, out)
+}
+
+// rejectDuringUpgrade 升级期间设备处于下载模式，其他请求直接返回 409
+func (s *Server) rejectDuringUpgrade(w http.ResponseWriter) bool {
+	s.mu.Lock()
+	upgrading := s.upgrade != nil && s.upgrade.State == upgradeRunning
+	s.mu.Unlock()
+
+	if upgrading {
+		writeErrorMessage(w, http.StatusConflict, errUpgrading.Error())
+	}
+
+	return upgrading
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize))
+	err := decoder.Decode(v)
+	if err != nil {
+		writeErrorMessage(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+		return false
+	}
+
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// errorResponse 错误信息，设备返回错误码时附带 code
+type errorResponse struct {
+	Error string `json:"error"`
+	Code  string `json:"code,omitempty"`
+}
+
+func writeErrorMessage(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
+
+// writeError 按错误类型返回状态码：名称不合法 400，设备拒绝执行 422，其他通信错误 502
+func writeError(w http.ResponseWriter, err error) {
+	if errors.Is(err, components.ErrInvalidName) {
+		writeErrorMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var tjcErr *client.TjcError
+	if errors.As(err, &tjcErr) {
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{
+			Error: err.Error(),
+			Code:  fmt.Sprintf("0x%02X", tjcErr.Code),
+		})
+		return
+	}
+
+	writeErrorMessage(w, http.StatusBadGateway, err.Error())
+}
@@ -0,0 +1,288 @@
+package httpapi
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/internal/apimodel"
+	"github.com/blue-cloud-net/tjc-serial-display/internal/client"
+	"github.com/blue-cloud-net/tjc-serial-display/internal/simulator"
+)
+
+// newTestServer 创建连接模拟设备的 API 服务
+func newTestServer(t *testing.T, device *simulator.Device) *httptest.Server {
+	t.Helper()
+
+	c := &client.TjcDisplayClient{
+		PortName: "sim",
+		BaudRate: 115200,
+		Timeout:  200 * time.Millisecond,
+		Opener:   device.Opener(),
+	}
+	t.Cleanup(func() { c.Close() })
+
+	server := httptest.NewServer((&Server{Client: c, TempDir: t.TempDir()}).Handler())
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func doJSON(t *testing.T, method, url, body string, out any) int {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", method, url, err)
+	}
+	defer resp.Body.Close()
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("Decode %s %s response failed: %v", method, url, err)
+		}
+	}
+
+	return resp.StatusCode
+}
+
+// readSSE 读取下一条 SSE 消息，返回事件名和数据
+func readSSE(t *testing.T, reader *bufio.Reader) (string, string) {
+	t.Helper()
+
+	var name, data string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("Read event stream failed: %v", err)
+		}
+
+		line = strings.TrimRight(line, "\n")
+		switch {
+		case line == "" && name != "":
+			return name, data
+		case strings.HasPrefix(line, "event: "):
+			name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func TestServer_DeviceAndPage(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	device.AddPage("page1")
+	server := newTestServer(t, device)
+
+	var info map[string]any
+	if status := doJSON(t, http.MethodGet, server.URL+"/api/device", "", &info); status != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", status)
+	}
+	if info["model"] != "TJC4024T032_011R" || info["serial_number"] != "D264B8204F0E1828" {
+		t.Errorf("Unexpected device info: %v", info)
+	}
+
+	var page map[string]int
+	if status := doJSON(t, http.MethodPut, server.URL+"/api/page", `{"page": 1}`, &page); status != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", status)
+	}
+	if device.Page() != 1 {
+		t.Errorf("Expected device on page 1, got %d", device.Page())
+	}
+
+	if status := doJSON(t, http.MethodGet, server.URL+"/api/page", "", &page); status != http.StatusOK || page["page"] != 1 {
+		t.Errorf("Expected page 1, got %d %v", status, page)
+	}
+
+	var errBody errorResponse
+	if status := doJSON(t, http.MethodPut, server.URL+"/api/page", `{"page": 9}`, &errBody); status != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 for invalid page, got %d", status)
+	}
+	if errBody.Code != "0x03" {
+		t.Errorf("Expected error code 0x03, got %+v", errBody)
+	}
+
+	if status := doJSON(t, http.MethodPut, server.URL+"/api/page", `{}`, nil); status != http.StatusBadRequest {
+		t.Errorf("Expected 400 for missing page, got %d", status)
+	}
+}
+
+func TestServer_ComponentAttributes(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	device.AddComponent(0, 1, "t0", map[string]any{"txt": "hello", "pco": int32(0)})
+	server := newTestServer(t, device)
+
+	var attr attributeBody
+	if status := doJSON(t, http.MethodGet, server.URL+"/api/components/t0/txt", "", &attr); status != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", status)
+	}
+	if attr.Value != "hello" {
+		t.Errorf("Expected hello, got %v", attr.Value)
+	}
+
+	if status := doJSON(t, http.MethodPut, server.URL+"/api/components/t0/txt", `{"value": "say \"hi\""}`, nil); status != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", status)
+	}
+	if got := device.Component(0, "t0").Attrs["txt"]; got != `say "hi"` {
+		t.Errorf("Expected escaped text to round trip, got %v", got)
+	}
+
+	if status := doJSON(t, http.MethodPut, server.URL+"/api/components/t0/pco", `{"value": 63488}`, nil); status != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", status)
+	}
+	if status := doJSON(t, http.MethodGet, server.URL+"/api/components/t0/pco", "", &attr); status != http.StatusOK || attr.Value != float64(63488) {
+		t.Errorf("Expected pco 63488, got %d %v", status, attr.Value)
+	}
+
+	if status := doJSON(t, http.MethodPut, server.URL+"/api/components/t0/txt", `{"value": true}`, nil); status != http.StatusBadRequest {
+		t.Errorf("Expected 400 for boolean value, got %d", status)
+	}
+	if status := doJSON(t, http.MethodGet, server.URL+"/api/components/t0/t%20xt", "", nil); status != http.StatusBadRequest {
+		t.Errorf("Expected 400 for invalid attribute, got %d", status)
+	}
+	if status := doJSON(t, http.MethodGet, server.URL+"/api/components/t0%3D1/txt", "", nil); status != http.StatusBadRequest {
+		t.Errorf("Expected 400 for invalid component name, got %d", status)
+	}
+	if status := doJSON(t, http.MethodGet, server.URL+"/api/components/t9/txt", "", nil); status != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 for unknown component, got %d", status)
+	}
+}
+
+func TestServer_Instruction(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	server := newTestServer(t, device)
+
+	var result instructionResponse
+	if status := doJSON(t, http.MethodPost, server.URL+"/api/instruction", `{"instruction": "sendme"}`, &result); status != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", status)
+	}
+	if value, ok := result.Value.(map[string]any); result.Type != "event" || !ok || value["type"] != "page" || value["page"] != float64(0) {
+		t.Errorf("Unexpected sendme result: %+v", result)
+	}
+
+	if status := doJSON(t, http.MethodPost, server.URL+"/api/instruction", `{"instruction": "page 0"}`, &result); status != http.StatusOK || result.Type != "success" {
+		t.Errorf("Expected success, got %d %+v", status, result)
+	}
+
+	if status := doJSON(t, http.MethodPost, server.URL+"/api/instruction", `{"instruction": "bogus"}`, nil); status != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 for invalid instruction, got %d", status)
+	}
+	if status := doJSON(t, http.MethodPost, server.URL+"/api/instruction", `{}`, nil); status != http.StatusBadRequest {
+		t.Errorf("Expected 400 for empty instruction, got %d", status)
+	}
+}
+
+func TestServer_Events(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	server := newTestServer(t, device)
+
+	// 先建立连接，事件由后台读取协程接收
+	if status := doJSON(t, http.MethodGet, server.URL+"/api/device", "", nil); status != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", status)
+	}
+
+	resp, err := http.Get(server.URL + "/api/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Expected text/event-stream, got %q", ct)
+	}
+
+	device.Touch(0, 2, true)
+
+	name, data := readSSE(t, bufio.NewReader(resp.Body))
+	if name != "touch" {
+		t.Errorf("Expected touch event, got %q", name)
+	}
+
+	var event apimodel.Event
+	if err := json.Unmarshal([]byte(data), &event); err != nil {
+		t.Fatal(err)
+	}
+	if event.Component != 2 || !event.Pressed || event.Code != "0x65" {
+		t.Errorf("Unexpected event: %+v", event)
+	}
+}
+
+func TestServer_Upgrade(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	server := newTestServer(t, device)
+
+	program := make([]byte, 9000)
+	copy(program[16:], "TJC4024T032_011R")
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, _ := form.CreateFormFile("file", "program.tft")
+	part.Write(program)
+	form.Close()
+
+	resp, err := http.Post(server.URL+"/api/upgrade", form.FormDataContentType(), &body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("Expected 202, got %d", resp.StatusCode)
+	}
+
+	events, err := http.Get(server.URL + "/api/upgrade/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer events.Body.Close()
+
+	reader := bufio.NewReader(events.Body)
+	var status upgradeStatus
+	for {
+		name, data := readSSE(t, reader)
+		if err := json.Unmarshal([]byte(data), &status); err != nil {
+			t.Fatal(err)
+		}
+		if name != "progress" {
+			if name != "done" {
+				t.Fatalf("Expected done, got %s: %+v", name, status)
+			}
+			break
+		}
+	}
+
+	if status.Current != 9000 || status.Total != 9000 {
+		t.Errorf("Unexpected final status: %+v", status)
+	}
+	if !bytes.Equal(device.UpgradeData(), program) {
+		t.Error("Expected device to receive the whole program")
+	}
+
+	// 流在升级结束后关闭
+	if _, err := io.ReadAll(reader); err != nil {
+		t.Errorf("Expected stream to end cleanly, got %v", err)
+	}
+}
+
+func TestServer_RejectDuringUpgrade(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	c := &client.TjcDisplayClient{PortName: "sim", Opener: device.Opener()}
+	s := &Server{Client: c, upgrade: &upgradeStatus{State: upgradeRunning}}
+
+	recorder := httptest.NewRecorder()
+	s.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/page", nil))
+	if recorder.Code != http.StatusConflict {
+		t.Errorf("Expected 409 during upgrade, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,223 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/internal/client"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/models"
+)
+
+const (
+	maxUploadMemory   = 32 << 20
+	watcherBufferSize = 16
+)
+
+// 升级状态
+const (
+	upgradeRunning = "running"
+	upgradeDone    = "done"
+	upgradeFailed  = "failed"
+)
+
+// upgradeStatus 升级状态和进度
+type upgradeStatus struct {
+	State      string  `json:"state"`
+	File       string  `json:"file"`
+	Current    int64   `json:"current"`
+	Total      int64   `json:"total"`
+	Percentage float64 `json:"percentage"`
+	Speed      int64   `json:"speed"`             // 字节/秒
+	Remaining  float64 `json:"remaining_seconds"` // 预计剩余秒数
+	Error      string  `json:"error,omitempty"`   // 失败原因
+	Offset     *int64  `json:"offset,omitempty"`  // 中断时设备已确认的字节数
+}
+
+// handleUpgrade 保存上传的程序文件并在后台升级，立即返回 202，进度通过 /api/upgrade/events 获取
+func (s *Server) handleUpgrade(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseMultipartForm(maxUploadMemory)
+	if err != nil {
+		writeErrorMessage(w, http.StatusBadRequest, "invalid multipart form: "+err.Error())
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		writeErrorMessage(w, http.StatusBadRequest, "file is required")
+		return
+	}
+	defer file.Close()
+
+	force, _ := strconv.ParseBool(r.FormValue("force"))
+
+	s.mu.Lock()
+	if s.upgrade != nil && s.upgrade.State == upgradeRunning {
+		s.mu.Unlock()
+		writeErrorMessage(w, http.StatusConflict, errUpgrading.Error())
+		return
+	}
+	status := &upgradeStatus{State: upgradeRunning, File: header.Filename, Total: header.Size}
+	s.upgrade = status
+	s.mu.Unlock()
+
+	path, err := s.saveUpload(file)
+	if err != nil {
+		s.finishUpgrade(err)
+		writeErrorMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	go func() {
+		defer os.Remove(path)
+
+		err := s.Client.UpgradeContext(context.Background(), path, &models.UpgradeOptions{
+			Force:    force,
+			Progress: s.reportProgress,
+		})
+		s.finishUpgrade(err)
+	}()
+
+	writeJSON(w, http.StatusAccepted, status.snapshot())
+}
+
+func (s *Server) saveUpload(file io.Reader) (string, error) {
+	f, err := os.CreateTemp(s.TempDir, "tjc-upgrade-*.tft")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, file)
+	if err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("failed to save program file: %w", err)
+	}
+
+	return f.Name(), nil
+}
+
+func (s *Server) reportProgress(progress *models.UpgradeProgress) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.upgrade.Current = progress.Current
+	s.upgrade.Total = progress.Total
+	s.upgrade.Percentage = progress.Percentage
+	s.upgrade.Speed = progress.Speed
+	s.upgrade.Remaining = progress.Remaining.Seconds()
+	s.notifyLocked()
+}
+
+func (s *Server) finishUpgrade(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err != nil {
+		s.upgrade.State = upgradeFailed
+		s.upgrade.Error = err.Error()
+
+		var upgradeErr *client.UpgradeError
+		if errors.As(err, &upgradeErr) {
+			offset := upgradeErr.Offset
+			s.upgrade.Offset = &offset
+		}
+	} else {
+		s.upgrade.State = upgradeDone
+		s.upgrade.Remaining = 0
+	}
+	s.notifyLocked()
+}
+
+// notifyLocked 向所有订阅者推送当前状态，订阅者处理不及时则丢弃中间进度
+func (s *Server) notifyLocked() {
+	for watcher := range s.watchers {
+		select {
+		case watcher <- s.upgrade.snapshot():
+		default:
+		}
+	}
+}
+
+func (st *upgradeStatus) snapshot() *upgradeStatus {
+	copied := *st
+	return &copied
+}
+
+func (s *Server) handleUpgradeStatus(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	var status *upgradeStatus
+	if s.upgrade != nil {
+		status = s.upgrade.snapshot()
+	}
+	s.mu.Unlock()
+
+	if status == nil {
+		writeErrorMessage(w, http.StatusNotFound, "no upgrade has been started")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, status)
+}
+
+// handleUpgradeEvents 以 SSE 推送升级进度，已有升级时先发送当前状态，升级结束后关闭
+// 事件名为 progress、done 或 failed
+func (s *Server) handleUpgradeEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := startSSE(w)
+	if !ok {
+		return
+	}
+
+	watcher := make(chan *upgradeStatus, watcherBufferSize)
+
+	s.mu.Lock()
+	if s.watchers == nil {
+		s.watchers = make(map[chan *upgradeStatus]struct{})
+	}
+	s.watchers[watcher] = struct{}{}
+	if s.upgrade != nil {
+		watcher <- s.upgrade.snapshot()
+	}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.watchers, watcher)
+		s.mu.Unlock()
+	}()
+
+	heartbeat := time.NewTicker(sseHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case status := <-watcher:
+			name := "progress"
+			if status.State != upgradeRunning {
+				name = status.State
+			}
+
+			if writeSSE(w, name, status) != nil {
+				return
+			}
+			flusher.Flush()
+
+			if status.State != upgradeRunning {
+				return
+			}
+		case <-heartbeat.C:
+			if writeSSEComment(w) != nil {
+				return
+			}
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,363 @@
+package mqttbridge
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/internal/apimodel"
+	"github.com/blue-cloud-net/tjc-serial-display/internal/client"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/models"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+const (
+	eventBufferSize = 64
+	qos             = 1
+
+	defaultMaxReconnectInterval = 30 * time.Second
+
+	// 退出时等待 offline 状态发出的时间
+	shutdownTimeout = time.Second
+)
+
+var errInvalidPayload = errors.New("invalid payload")
+
+// Bridge 将显示屏接入 MQTT（tjc mqtt），主题均以 Prefix 开头
+//
+//	<prefix>/status                   桥接状态 online/offline（保留，offline 同时作为遗嘱消息）
+//	<prefix>/device                   设备信息和串口连接状态（JSON，保留）
+//	<prefix>/event/<type>             最近一次设备事件，如 event/touch、event/page、event/sleep（JSON，保留）
+//	<prefix>/set/<component>/<attr>   设置控件属性，负载为字符串或整数
+//	<prefix>/page                     跳转页面，负载为页面ID
+//	<prefix>/exec                     执行原始指令，负载为指令
+//	<prefix>/result                   命令的执行结果（JSON）
+//
+// broker 断开后自动重连，重连后重新订阅命令主题并重新发布保留消息；
+// 串口断开和重连由客户端完成（TjcDisplayClient.AutoReconnect），桥接据此更新 device 主题
+type Bridge struct {
+	Client               *client.TjcDisplayClient
+	Broker               string // broker 地址，如 tcp://localhost:1883
+	Prefix               string // 主题前缀，如 plant/line1/hmi
+	ClientID             string // MQTT 客户端ID，为空时随机生成
+	Username             string
+	Password             string
+	MaxReconnectInterval time.Duration                    // broker 重连的最长间隔，默认 30s
+	Logf                 func(format string, args ...any) // 连接状态日志，为空时不输出
+
+	mu       sync.Mutex
+	mqtt     mqtt.Client
+	retained map[string][]byte // 已发布的保留消息，重连 broker 后重新发布
+	device   deviceBody        // 最近一次获取的设备信息
+}
+
+// deviceBody 设备信息和串口连接状态，串口断开时保留最近一次获取的设备信息
+type deviceBody struct {
+	Connected bool   `json:"connected"`
+	Port      string `json:"port"`
+	BaudRate  int    `json:"baud_rate"`
+	Error     string `json:"error,omitempty"` // 设备没有应答等连接失败的原因
+	apimodel.DeviceInfo
+}
+
+// resultBody 命令的执行结果
+type resultBody struct {
+	Topic string `json:"topic"`           // 命令主题（不含前缀），如 set/t0/txt
+	Error string `json:"error,omitempty"` // 执行失败的原因
+	Code  string `json:"code,omitempty"`  // 设备返回的错误码，如 0x1A
+	Hex   string `json:"hex,omitempty"`   // exec 命令的设备原始应答
+}
+
+// Run 连接 broker 并开始桥接，直到 ctx 取消。调用前客户端应已打开
+func (b *Bridge) Run(ctx context.Context) error {
+	if b.Client == nil {
+		return errors.New("client is required")
+	}
+	if b.Broker == "" {
+		return errors.New("broker is required")
+	}
+	b.Prefix = strings.TrimSuffix(b.Prefix, "/")
+	if b.Prefix == "" {
+		return errors.New("prefix is required")
+	}
+
+	b.mu.Lock()
+	b.retained = make(map[string][]byte)
+	b.device = deviceBody{Port: b.Client.PortName, BaudRate: b.Client.BaudRate}
+	b.mu.Unlock()
+
+	events, unsubscribe := b.Client.Events(eventBufferSize)
+	defer unsubscribe()
+
+	// 回调在客户端的读写协程中执行，交给 Run 协程处理
+	states := make(chan *models.ConnectionEvent, eventBufferSize)
+	unsubscribeConnection := b.Client.SubscribeConnection(func(event *models.ConnectionEvent) {
+		select {
+		case states <- event:
+		default:
+		}
+	})
+	defer unsubscribeConnection()
+
+	b.refreshDevice(ctx)
+
+	clientID := b.ClientID
+	if clientID == "" {
+		clientID = randomClientID()
+	}
+
+	opts := mqtt.NewClientOptions().
+		AddBroker(b.Broker).
+		SetClientID(clientID).
+		SetUsername(b.Username).
+		SetPassword(b.Password).
+		SetCleanSession(true).
+		SetOrderMatters(false).
+		SetAutoReconnect(true).
+		SetConnectRetry(true).
+		SetConnectRetryInterval(time.Second).
+		SetMaxReconnectInterval(b.maxReconnectInterval()).
+		SetWill(b.topic("status"), "offline", qos, true).
+		SetOnConnectHandler(b.onConnect).
+		SetConnectionLostHandler(func(_ mqtt.Client, err error) {
+			b.logf("broker connection lost: %v", err)
+		})
+
+	b.mu.Lock()
+	b.mqtt = mqtt.NewClient(opts)
+	b.mu.Unlock()
+
+	// 开启连接重试后 Connect 在连接成功前不会完成，连接成功后由 onConnect 完成订阅和发布
+	b.mqtt.Connect()
+
+	defer func() {
+		if b.mqtt.IsConnectionOpen() {
+			b.mqtt.Publish(b.topic("status"), qos, true, "offline").WaitTimeout(shutdownTimeout)
+		}
+		b.mqtt.Disconnect(250)
+	}()
+
+	for {
+		select {
+		case event := <-events:
+			b.publishEvent(event)
+		case state := <-states:
+			b.handleConnection(ctx, state)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// onConnect 连接（或重连）broker 后订阅命令主题，并重新发布所有保留消息
+func (b *Bridge) onConnect(c mqtt.Client) {
+	b.logf("connected to broker %s", b.Broker)
+
+	filters := map[string]byte{
+		b.topic("set/+/+"): qos,
+		b.topic("page"):    qos,
+		b.topic("exec"):    qos,
+	}
+	token := c.SubscribeMultiple(filters, b.onCommand)
+	if token.Wait() && token.Error() != nil {
+		b.logf("subscribe failed: %v", token.Error())
+	}
+
+	c.Publish(b.topic("status"), qos, true, "online")
+
+	b.mu.Lock()
+	retained := make(map[string][]byte, len(b.retained))
+	for topic, payload := range b.retained {
+		retained[topic] = payload
+	}
+	b.mu.Unlock()
+
+	for topic, payload := range retained {
+		c.Publish(topic, qos, true, payload)
+	}
+}
+
+// onCommand 执行命令主题收到的消息，并将结果发布到 <prefix>/result
+// 保留的命令消息会在每次重连时重新投递，因此忽略
+func (b *Bridge) onCommand(c mqtt.Client, msg mqtt.Message) {
+	if msg.Retained() {
+		return
+	}
+
+	name := strings.TrimPrefix(msg.Topic(), b.Prefix+"/")
+	result := &resultBody{Topic: name}
+
+	err := b.execute(name, msg.Payload(), result)
+	if err != nil {
+		result.Error = err.Error()
+
+		var tjcErr *client.TjcError
+		if errors.As(err, &tjcErr) {
+			result.Code = fmt.Sprintf("0x%02X", tjcErr.Code)
+		}
+	}
+
+	payload, _ := json.Marshal(result)
+	c.Publish(b.topic("result"), qos, false, payload)
+}
+
+// execute 将命令转换为 TJC 指令执行
+func (b *Bridge) execute(name string, payload []byte, result *resultBody) error {
+	switch {
+	case name == "page":
+		page, err := strconv.Atoi(strings.TrimSpace(string(payload)))
+		if err != nil || page < 0 {
+			return fmt.Errorf("%w: page must be a non-negative integer", errInvalidPayload)
+		}
+
+		return b.Client.JumpPage(page)
+	case name == "exec":
+		instruction := strings.TrimSpace(string(payload))
+		if instruction == "" {
+			return fmt.Errorf("%w: instruction is required", errInvalidPayload)
+		}
+
+		data, err := b.Client.ExecuteCommand(instruction)
+		if err != nil {
+			return err
+		}
+		result.Hex = strings.ToUpper(hex.EncodeToString(data))
+
+		resp, err := client.ParseResponse(data)
+		if err == nil && resp.Type == client.ResponseTypeError {
+			return resp.Err()
+		}
+
+		return nil
+	case strings.HasPrefix(name, "set/"):
+		componentName, attr, ok := strings.Cut(strings.TrimPrefix(name, "set/"), "/")
+		if !ok || !apimodel.IsIdentifier(attr) {
+			return fmt.Errorf("invalid attribute in topic %q", name)
+		}
+
+		// 借助控件句柄完成名称校验和字符串转义
+		component := &b.Client.Text(componentName).Component
+		text, number, isText := parseValue(payload)
+		if isText {
+			return component.SetString(attr, text)
+		}
+
+		return component.SetNumber(attr, number)
+	}
+
+	return fmt.Errorf("unknown command topic %q", name)
+}
+
+// parseValue 解析属性值：JSON 字符串或 32 位整数，其余内容按原样作为字符串
+func parseValue(payload []byte) (string, int32, bool) {
+	var text string
+	if json.Unmarshal(payload, &text) == nil {
+		return text, 0, true
+	}
+
+	var number int32
+	if json.Unmarshal(payload, &number) == nil {
+		return "", number, false
+	}
+
+	return string(payload), 0, true
+}
+
+// publishEvent 将设备事件发布到 <prefix>/event/<type>
+func (b *Bridge) publishEvent(event *models.Event) {
+	b.publishRetained(b.topic("event/"+event.Type.String()), apimodel.NewEvent(event))
+}
+
+// refreshDevice 获取设备信息并发布连接状态，设备没有应答时发布 connected=false 和失败原因
+func (b *Bridge) refreshDevice(ctx context.Context) {
+	info, err := b.Client.GetDeviceInfoContext(ctx)
+
+	b.mu.Lock()
+	b.device.Connected = err == nil
+	b.device.BaudRate = b.Client.BaudRate
+	b.device.Error = ""
+	if err == nil {
+		b.device.DeviceInfo = apimodel.NewDeviceInfo(info)
+	} else {
+		b.device.Error = err.Error()
+	}
+	device := b.device
+	b.mu.Unlock()
+
+	if err != nil {
+		b.logf("get device info failed: %v", err)
+	}
+
+	b.publishRetained(b.topic("device"), &device)
+}
+
+// handleConnection 串口断开时发布 connected=false，重新连接后重新获取设备信息
+func (b *Bridge) handleConnection(ctx context.Context, state *models.ConnectionEvent) {
+	if state.Connected {
+		b.logf("serial port %s reconnected", b.Client.PortName)
+		b.refreshDevice(ctx)
+		return
+	}
+
+	b.logf("serial port %s lost: %v", b.Client.PortName, state.Err)
+
+	b.mu.Lock()
+	b.device.Connected = false
+	if state.Err != nil {
+		b.device.Error = state.Err.Error()
+	}
+	device := b.device
+	b.mu.Unlock()
+
+	b.publishRetained(b.topic("device"), &device)
+}
+
+// publishRetained 以 JSON 发布保留消息，并记录下来供重连 broker 后重新发布
+func (b *Bridge) publishRetained(topic string, v any) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+
+	b.mu.Lock()
+	b.retained[topic] = payload
+	c := b.mqtt
+	b.mu.Unlock()
+
+	// 未连接时不发布，连接成功后由 onConnect 发布
+	if c != nil && c.IsConnectionOpen() {
+		c.Publish(topic, qos, true, payload)
+	}
+}
+
+func (b *Bridge) topic(name string) string {
+	return b.Prefix + "/" + name
+}
+
+func (b *Bridge) maxReconnectInterval() time.Duration {
+	if b.MaxReconnectInterval > 0 {
+		return b.MaxReconnectInterval
+	}
+
+	return defaultMaxReconnectInterval
+}
+
+func (b *Bridge) logf(format string, args ...any) {
+	if b.Logf != nil {
+		b.Logf(format, args...)
+	}
+}
+
+func randomClientID() string {
+	id := make([]byte, 4)
+	_, _ = rand.Read(id)
+
+	return "tjc-" + hex.EncodeToString(id)
+}
@@ -0,0 +1,264 @@
+package mqttbridge
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/internal/apimodel"
+	"github.com/blue-cloud-net/tjc-serial-display/internal/client"
+	"github.com/blue-cloud-net/tjc-serial-display/internal/simulator"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+const testPrefix = "plant/line1/hmi"
+
+// startBridge 打开连接模拟设备的客户端，并在后台运行桥接直到测试结束
+func startBridge(t *testing.T, device *simulator.Device, broker *testBroker) {
+	t.Helper()
+
+	startBridgeAt(t, device, broker, 115200)
+}
+
+// startBridgeAt 以指定波特率连接模拟设备并启动桥接
+func startBridgeAt(t *testing.T, device *simulator.Device, broker *testBroker, baudRate int) {
+	t.Helper()
+
+	c := &client.TjcDisplayClient{
+		PortName:             "sim",
+		BaudRate:             baudRate,
+		Timeout:              200 * time.Millisecond,
+		Opener:               device.Opener(),
+		AutoReconnect:        true,
+		MaxReconnectInterval: time.Second,
+	}
+	if err := c.Open(); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+
+	bridge := &Bridge{
+		Client:               c,
+		Broker:               broker.URL(),
+		Prefix:               testPrefix,
+		MaxReconnectInterval: 2 * time.Second,
+		Logf:                 t.Logf,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- bridge.Run(ctx) }()
+
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Run failed: %v", err)
+		}
+	})
+}
+
+// watcher 订阅桥接主题的测试客户端，同时用于发送命令
+type watcher struct {
+	client   mqtt.Client
+	messages chan mqtt.Message
+}
+
+func newWatcher(t *testing.T, broker *testBroker) *watcher {
+	t.Helper()
+
+	w := &watcher{messages: make(chan mqtt.Message, 256)}
+	opts := mqtt.NewClientOptions().
+		AddBroker(broker.URL()).
+		SetClientID("watcher").
+		SetAutoReconnect(true).
+		SetOnConnectHandler(func(c mqtt.Client) {
+			c.Subscribe(testPrefix+"/#", 0, func(_ mqtt.Client, msg mqtt.Message) {
+				w.messages <- msg
+			}).Wait()
+		})
+	w.client = mqtt.NewClient(opts)
+
+	token := w.client.Connect()
+	if !token.WaitTimeout(5*time.Second) || token.Error() != nil {
+		t.Fatalf("Watcher connect failed: %v", token.Error())
+	}
+	t.Cleanup(func() { w.client.Disconnect(0) })
+
+	return w
+}
+
+// wait 等待主题收到满足条件的消息并返回其负载
+func (w *watcher) wait(t *testing.T, topic string, match func(payload []byte) bool) []byte {
+	t.Helper()
+
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case msg := <-w.messages:
+			if msg.Topic() == testPrefix+"/"+topic && (match == nil || match(msg.Payload())) {
+				return msg.Payload()
+			}
+		case <-timeout:
+			t.Fatalf("Timeout waiting for %s", topic)
+			return nil
+		}
+	}
+}
+
+// command 发送命令并等待执行结果
+func (w *watcher) command(t *testing.T, topic, payload string) *resultBody {
+	t.Helper()
+
+	w.client.Publish(testPrefix+"/"+topic, 1, false, payload).Wait()
+
+	var result resultBody
+	w.wait(t, "result", func(payload []byte) bool {
+		return json.Unmarshal(payload, &result) == nil && result.Topic == topic
+	})
+
+	return &result
+}
+
+func payloadEquals(expected string) func([]byte) bool {
+	return func(payload []byte) bool { return string(payload) == expected }
+}
+
+func deviceConnected(connected bool) func([]byte) bool {
+	return func(payload []byte) bool {
+		var device deviceBody
+		return json.Unmarshal(payload, &device) == nil && device.Connected == connected
+	}
+}
+
+func TestBridge_PublishesDeviceAndEvents(t *testing.T) {
+	broker := newTestBroker(t)
+	w := newWatcher(t, broker)
+
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	startBridge(t, device, broker)
+
+	w.wait(t, "status", payloadEquals("online"))
+
+	var info deviceBody
+	err := json.Unmarshal(w.wait(t, "device", deviceConnected(true)), &info)
+	if err != nil || info.Model != "TJC4024T032_011R" || info.SerialNumber != "D264B8204F0E1828" {
+		t.Errorf("Unexpected device info: %+v, %v", info, err)
+	}
+
+	device.Touch(0, 2, true)
+
+	var event apimodel.Event
+	err = json.Unmarshal(w.wait(t, "event/touch", nil), &event)
+	if err != nil || event.Type != "touch" || event.Component != 2 || !event.Pressed {
+		t.Errorf("Unexpected touch event: %+v, %v", event, err)
+	}
+
+	// 设备信息和事件均为保留消息
+	for _, topic := range []string{"status", "device", "event/touch"} {
+		if _, ok := broker.Retained(testPrefix + "/" + topic); !ok {
+			t.Errorf("Expected %s to be retained", topic)
+		}
+	}
+}
+
+func TestBridge_Commands(t *testing.T) {
+	broker := newTestBroker(t)
+	w := newWatcher(t, broker)
+
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	device.AddPage("page1")
+	device.AddComponent(0, 1, "t0", map[string]any{"txt": ""})
+	device.AddComponent(0, 2, "n0", map[string]any{"val": int32(0)})
+	startBridge(t, device, broker)
+	w.wait(t, "status", payloadEquals("online"))
+
+	if result := w.command(t, "set/t0/txt", "hello"); result.Error != "" {
+		t.Fatalf("set text failed: %+v", result)
+	}
+	if txt := device.Component(0, "t0").Attrs["txt"]; txt != "hello" {
+		t.Errorf("Expected t0.txt hello, got %v", txt)
+	}
+
+	if result := w.command(t, "set/n0/val", "42"); result.Error != "" {
+		t.Fatalf("set number failed: %+v", result)
+	}
+	if val := device.Component(0, "n0").Attrs["val"]; val != int32(42) {
+		t.Errorf("Expected n0.val 42, got %v", val)
+	}
+
+	if result := w.command(t, "exec", "get n0.val"); result.Error != "" || result.Hex != "712A000000FFFFFF" {
+		t.Errorf("Unexpected exec result: %+v", result)
+	}
+
+	if result := w.command(t, "page", "1"); result.Error != "" {
+		t.Fatalf("page failed: %+v", result)
+	}
+	if device.Page() != 1 {
+		t.Errorf("Expected page 1, got %d", device.Page())
+	}
+
+	// 设备错误码随结果返回
+	if result := w.command(t, "page", "9"); result.Code != "0x03" {
+		t.Errorf("Expected error code 0x03, got %+v", result)
+	}
+	if result := w.command(t, "page", "next"); result.Error == "" {
+		t.Error("Expected invalid payload error")
+	}
+}
+
+func TestBridge_BrokerReconnect(t *testing.T) {
+	broker := newTestBroker(t)
+	w := newWatcher(t, broker)
+
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	device.AddComponent(0, 1, "t0", map[string]any{"txt": ""})
+	startBridge(t, device, broker)
+	w.wait(t, "status", payloadEquals("online"))
+
+	broker.DropClients()
+
+	// 非正常断开时 broker 发布遗嘱消息，重连后桥接重新上线并重新订阅命令主题
+	w.wait(t, "status", payloadEquals("offline"))
+	w.wait(t, "status", payloadEquals("online"))
+
+	if result := w.command(t, "set/t0/txt", "again"); result.Error != "" {
+		t.Fatalf("set text after reconnect failed: %+v", result)
+	}
+	if txt := device.Component(0, "t0").Attrs["txt"]; txt != "again" {
+		t.Errorf("Expected t0.txt again, got %v", txt)
+	}
+}
+
+func TestBridge_SerialReconnect(t *testing.T) {
+	broker := newTestBroker(t)
+	w := newWatcher(t, broker)
+
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	startBridge(t, device, broker)
+	w.wait(t, "device", deviceConnected(true))
+
+	device.Unplug()
+	w.wait(t, "device", deviceConnected(false))
+
+	device.Plug()
+	w.wait(t, "device", deviceConnected(true))
+
+	device.Touch(0, 3, false)
+	w.wait(t, "event/touch", nil)
+}
+
+func TestBridge_DeviceNotAnswering(t *testing.T) {
+	broker := newTestBroker(t)
+	w := newWatcher(t, broker)
+
+	// 波特率不一致，串口能打开但设备没有应答
+	device := simulator.New(simulator.Config{BaudRate: 9600})
+	startBridgeAt(t, device, broker, 115200)
+
+	var info deviceBody
+	err := json.Unmarshal(w.wait(t, "device", nil), &info)
+	if err != nil || info.Connected || info.Error == "" {
+		t.Errorf("Expected disconnected device with error, got %+v, %v", info, err)
+	}
+}
@@ -0,0 +1,234 @@
+package mqttbridge
+
+import (
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/eclipse/paho.mqtt.golang/packets"
+)
+
+// testBroker 测试用的最小 MQTT 3.1.1 broker：支持订阅通配符、保留消息和遗嘱消息，
+// 转发给订阅者的消息统一使用 QoS 0
+type testBroker struct {
+	listener net.Listener
+
+	mu       sync.Mutex
+	sessions map[*brokerSession]struct{}
+	retained map[string][]byte
+}
+
+type brokerSession struct {
+	conn    net.Conn
+	writeMu sync.Mutex
+	filters map[string]struct{}
+	will    *packets.PublishPacket
+}
+
+func newTestBroker(t *testing.T) *testBroker {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := &testBroker{
+		listener: listener,
+		sessions: make(map[*brokerSession]struct{}),
+		retained: make(map[string][]byte),
+	}
+	go b.serve()
+	t.Cleanup(b.close)
+
+	return b
+}
+
+func (b *testBroker) URL() string {
+	return "tcp://" + b.listener.Addr().String()
+}
+
+// Retained 返回主题当前的保留消息
+func (b *testBroker) Retained(topic string) ([]byte, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	payload, ok := b.retained[topic]
+	return payload, ok
+}
+
+// DropClients 断开所有客户端连接，模拟 broker 重启或网络中断
+func (b *testBroker) DropClients() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for s := range b.sessions {
+		s.conn.Close()
+	}
+}
+
+func (b *testBroker) close() {
+	b.listener.Close()
+	b.DropClients()
+}
+
+func (b *testBroker) serve() {
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go b.handle(conn)
+	}
+}
+
+func (b *testBroker) handle(conn net.Conn) {
+	s := &brokerSession{conn: conn, filters: make(map[string]struct{})}
+
+	b.mu.Lock()
+	b.sessions[s] = struct{}{}
+	b.mu.Unlock()
+
+	clean := false
+	defer func() {
+		b.mu.Lock()
+		delete(b.sessions, s)
+		b.mu.Unlock()
+		conn.Close()
+
+		// 非正常断开时发布遗嘱消息
+		if !clean && s.will != nil {
+			b.publish(s.will)
+		}
+	}()
+
+	for {
+		packet, err := packets.ReadPacket(conn)
+		if err != nil {
+			return
+		}
+
+		switch p := packet.(type) {
+		case *packets.ConnectPacket:
+			if p.WillFlag {
+				will := packets.NewControlPacket(packets.Publish).(*packets.PublishPacket)
+				will.TopicName = p.WillTopic
+				will.Payload = p.WillMessage
+				will.Retain = p.WillRetain
+				s.will = will
+			}
+			s.write(packets.NewControlPacket(packets.Connack))
+		case *packets.SubscribePacket:
+			ack := packets.NewControlPacket(packets.Suback).(*packets.SubackPacket)
+			ack.MessageID = p.MessageID
+			ack.ReturnCodes = p.Qoss
+
+			b.mu.Lock()
+			for _, filter := range p.Topics {
+				s.filters[filter] = struct{}{}
+			}
+			var retained []*packets.PublishPacket
+			for topic, payload := range b.retained {
+				for _, filter := range p.Topics {
+					if topicMatches(filter, topic) {
+						retained = append(retained, newPublish(topic, payload, true))
+						break
+					}
+				}
+			}
+			b.mu.Unlock()
+
+			s.write(ack)
+			for _, publish := range retained {
+				s.write(publish)
+			}
+		case *packets.UnsubscribePacket:
+			b.mu.Lock()
+			for _, filter := range p.Topics {
+				delete(s.filters, filter)
+			}
+			b.mu.Unlock()
+
+			ack := packets.NewControlPacket(packets.Unsuback).(*packets.UnsubackPacket)
+			ack.MessageID = p.MessageID
+			s.write(ack)
+		case *packets.PublishPacket:
+			if p.Qos > 0 {
+				ack := packets.NewControlPacket(packets.Puback).(*packets.PubackPacket)
+				ack.MessageID = p.MessageID
+				s.write(ack)
+			}
+			b.publish(p)
+		case *packets.PingreqPacket:
+			s.write(packets.NewControlPacket(packets.Pingresp))
+		case *packets.DisconnectPacket:
+			clean = true
+			return
+		}
+	}
+}
+
+// publish 记录保留消息并转发给所有匹配的订阅者
+func (b *testBroker) publish(p *packets.PublishPacket) {
+	b.mu.Lock()
+	if p.Retain {
+		if len(p.Payload) == 0 {
+			delete(b.retained, p.TopicName)
+		} else {
+			b.retained[p.TopicName] = p.Payload
+		}
+	}
+
+	var targets []*brokerSession
+	for s := range b.sessions {
+		for filter := range s.filters {
+			if topicMatches(filter, p.TopicName) {
+				targets = append(targets, s)
+				break
+			}
+		}
+	}
+	b.mu.Unlock()
+
+	for _, s := range targets {
+		s.write(newPublish(p.TopicName, p.Payload, false))
+	}
+}
+
+func (s *brokerSession) write(packet packets.ControlPacket) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	_ = packet.Write(s.conn)
+}
+
+func newPublish(topic string, payload []byte, retain bool) *packets.PublishPacket {
+	p := packets.NewControlPacket(packets.Publish).(*packets.PublishPacket)
+	p.TopicName = topic
+	p.Payload = payload
+	p.Retain = retain
+
+	return p
+}
+
+// topicMatches 判断主题是否匹配订阅过滤器（支持 + 和 #）
+func topicMatches(filter, topic string) bool {
+	filterLevels := strings.Split(filter, "/")
+	topicLevels := strings.Split(topic, "/")
+
+	for i, level := range filterLevels {
+		if level == "#" {
+			return true
+		}
+		if i >= len(topicLevels) {
+			return false
+		}
+		if level != "+" && level != topicLevels[i] {
+			return false
+		}
+	}
+
+	return len(filterLevels) == len(topicLevels)
+}
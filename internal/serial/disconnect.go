@@ -0,0 +1,85 @@
+package serial
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+	"time"
+
+	"go.bug.st/serial"
+)
+
+// ErrDisconnected 设备已断开（如 USB 转串口被拔出、网络串口连接中断），需要重新打开串口
+var ErrDisconnected = errors.New("serial port disconnected")
+
+// IsDisconnectError 判断传输通道返回的错误是否表示设备已经消失，之后的读写都会失败
+func IsDisconnectError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	// Linux 下设备拔出后串口一直可读但读不到数据，go.bug.st/serial 返回 PortClosed
+	var portErr *serial.PortError
+	if errors.As(err, &portErr) {
+		return portErr.Code() == serial.PortClosed
+	}
+
+	return errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrClosedPipe) ||
+		errors.Is(err, net.ErrClosed) ||
+		errors.Is(err, ErrPortClosed) ||
+		errors.Is(err, syscall.EIO) ||
+		errors.Is(err, syscall.ENXIO) ||
+		errors.Is(err, syscall.ENODEV) ||
+		errors.Is(err, syscall.EBADF) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, syscall.ECONNRESET)
+}
+
+// watchedTransport 检查传输通道返回的错误，设备断开时关闭通道并将管理器标记为已关闭
+type watchedTransport struct {
+	Transport
+	manager *SerialPortManager
+}
+
+func (t *watchedTransport) Read(p []byte) (int, error) {
+	n, err := t.Transport.Read(p)
+	return n, t.check(err)
+}
+
+func (t *watchedTransport) Write(p []byte) (int, error) {
+	n, err := t.Transport.Write(p)
+	return n, t.check(err)
+}
+
+func (t *watchedTransport) SetMode(mode *serial.Mode) error {
+	return t.check(t.Transport.SetMode(mode))
+}
+
+func (t *watchedTransport) SetReadTimeout(timeout time.Duration) error {
+	return t.check(t.Transport.SetReadTimeout(timeout))
+}
+
+func (t *watchedTransport) Drain() error {
+	return t.check(t.Transport.Drain())
+}
+
+// check 设备断开时返回包装了 ErrDisconnected 的错误，并通知管理器；
+// 只通知一次，主动调用 Close 之后也不再通知
+func (t *watchedTransport) check(err error) error {
+	if !IsDisconnectError(err) {
+		return err
+	}
+
+	if !t.manager.closed.Swap(true) {
+		_ = t.Transport.Close()
+
+		if t.manager.OnDisconnect != nil {
+			t.manager.OnDisconnect(err)
+		}
+	}
+
+	return fmt.Errorf("%w: %w", ErrDisconnected, err)
+}
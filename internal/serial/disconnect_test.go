@@ -0,0 +1,144 @@
+package serial
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"syscall"
+	"testing"
+
+	"go.bug.st/serial"
+)
+
+func TestIsDisconnectError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected bool
+	}{
+		{nil, false},
+		{errors.New("read timeout"), false},
+		{io.EOF, true},
+		{fmt.Errorf("read: %w", syscall.EIO), true},
+		{syscall.ENODEV, true},
+		{&serial.PortError{}, false},
+		{ErrPortClosed, true},
+	}
+
+	for _, tt := range tests {
+		if got := IsDisconnectError(tt.err); got != tt.expected {
+			t.Errorf("IsDisconnectError(%v) = %v, expected %v", tt.err, got, tt.expected)
+		}
+	}
+}
+
+// TestSerialPortManager_Disconnect 测试读写检测到设备断开后串口标记为关闭，并且只通知一次
+func TestSerialPortManager_Disconnect(t *testing.T) {
+	fake := &fakeTransport{}
+	var notified []error
+
+	spm := &SerialPortManager{
+		PortName: "fake0",
+		Opener: func(portName string, mode *serial.Mode) (Transport, error) {
+			return fake, nil
+		},
+		OnDisconnect: func(err error) {
+			notified = append(notified, err)
+		},
+	}
+	if err := spm.Open(); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	// 普通错误不视为断开
+	fake.readErr = errors.New("parity error")
+	if _, err := spm.Read(); err == nil || errors.Is(err, ErrDisconnected) {
+		t.Errorf("Expected plain read error, got %v", err)
+	}
+	if !spm.IsOpen() {
+		t.Fatal("Expected port to stay open after a plain error")
+	}
+
+	fake.readErr = syscall.EIO
+	_, err := spm.Read()
+	if !errors.Is(err, ErrDisconnected) || !errors.Is(err, syscall.EIO) {
+		t.Errorf("Expected ErrDisconnected wrapping EIO, got %v", err)
+	}
+	if spm.IsOpen() {
+		t.Error("Expected port to be closed after disconnect")
+	}
+	if !fake.closed {
+		t.Error("Expected transport to be closed after disconnect")
+	}
+	if len(notified) != 1 {
+		t.Errorf("Expected one disconnect notification, got %d", len(notified))
+	}
+
+	if err := spm.Write([]byte("sendme")); err == nil {
+		t.Error("Expected write to fail after disconnect")
+	}
+	if err := spm.Close(); err != nil {
+		t.Errorf("Expected closing a disconnected port to succeed, got %v", err)
+	}
+
+	// 重新打开后恢复正常
+	fake.readErr = nil
+	fake.closed = false
+	if err := spm.Open(); err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	if !spm.IsOpen() {
+		t.Error("Expected port to be open after reopen")
+	}
+	spm.Close()
+	if len(notified) != 1 {
+		t.Errorf("Expected Close not to notify, got %d notifications", len(notified))
+	}
+}
+
+// TestSerialPortManager_ReadUntilEOF 测试数据流结束（设备断开）时读取函数返回包装了 ErrDisconnected 的错误
+func TestSerialPortManager_ReadUntilEOF(t *testing.T) {
+	fake := &fakeTransport{readErr: io.EOF}
+	spm := &SerialPortManager{
+		PortName:    "fake0",
+		BytesToRead: 16,
+		Opener: func(portName string, mode *serial.Mode) (Transport, error) {
+			return fake, nil
+		},
+	}
+
+	open := func(input string) {
+		t.Helper()
+		fake.input.WriteString(input)
+		if err := spm.Open(); err != nil {
+			t.Fatalf("Open failed: %v", err)
+		}
+	}
+
+	open("abc")
+	data, err := spm.ReadUntil([]byte{0xFF, 0xFF, 0xFF})
+	if !errors.Is(err, ErrDisconnected) || !errors.Is(err, io.EOF) || data != nil {
+		t.Errorf("ReadUntil: expected ErrDisconnected wrapping EOF, got %q, %v", data, err)
+	}
+	if spm.IsOpen() {
+		t.Error("Expected port to be closed after EOF")
+	}
+
+	open("abcdef")
+	data, err = spm.ReadAll(0)
+	if !errors.Is(err, ErrDisconnected) || string(data) != "abcdef" {
+		t.Errorf("ReadAll: expected abcdef with ErrDisconnected, got %q, %v", data, err)
+	}
+
+	open("ab")
+	data, err = spm.ReadExactly(4)
+	if !errors.Is(err, ErrDisconnected) || string(data) != "ab" {
+		t.Errorf("ReadExactly: expected ab with ErrDisconnected, got %q, %v", data, err)
+	}
+
+	open("a")
+	data, err = spm.ReadUntilContext(context.Background(), []byte{0xFF, 0xFF, 0xFF})
+	if !errors.Is(err, ErrDisconnected) || data != nil {
+		t.Errorf("ReadUntilContext: expected ErrDisconnected, got %q, %v", data, err)
+	}
+}
@@ -2,14 +2,19 @@ package serial
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
-	"io"
+	"sync/atomic"
 	"time"
 
 	"go.bug.st/serial"
+	"go.bug.st/serial/enumerator"
 )
 
+// context 版本读取时的轮询间隔，每个周期检查一次 context 是否已取消
+const contextPollInterval = 20 * time.Millisecond
+
 type SerialPortManager struct {
 	PortName    string
 	BaudRate    int
@@ -18,13 +23,28 @@ type SerialPortManager struct {
 	StopBits    serial.StopBits
 	Timeout     time.Duration
 	BytesToRead int
-	port        serial.Port
+	Opener      Opener // 传输通道打开方式，为空时按端口名使用系统串口或网络串口（OpenPort）
+
+	// OnDisconnect 读写时检测到设备断开（如 USB 转串口被拔出）后调用，此时串口已关闭，
+	// 在出错的读写所在协程中执行，不应阻塞
+	OnDisconnect func(err error)
+
+	port   Transport
+	closed atomic.Bool // 已关闭或设备已断开
 }
 
 func ListPorts() ([]string, error) {
 	return serial.GetPortsList()
 }
 
+// PortDetails 串口详细信息，USB 转串口包含 VID、PID 和 USB 序列号
+type PortDetails = enumerator.PortDetails
+
+// ListPortDetails 列出串口及其 USB 信息（Linux 下读取 sysfs）
+func ListPortDetails() ([]*PortDetails, error) {
+	return enumerator.GetDetailedPortsList()
+}
+
 func (spm *SerialPortManager) Open() error {
 	// 设置默认值
 	if spm.BaudRate == 0 {
@@ -60,19 +80,26 @@ func (spm *SerialPortManager) Open() error {
 		StopBits: spm.StopBits,
 	}
 
-	port, err := serial.Open(spm.PortName, mode)
+	opener := spm.Opener
+	if opener == nil {
+		opener = OpenPort
+	}
+
+	port, err := opener(spm.PortName, mode)
 	if err != nil {
 		return err
 	}
 
 	port.SetReadTimeout(spm.Timeout)
 
-	spm.port = port
+	spm.port = &watchedTransport{Transport: port, manager: spm}
+	spm.closed.Store(false)
 	return nil
 }
 
+// IsOpen 串口已打开且没有关闭，设备断开后返回 false
 func (spm *SerialPortManager) IsOpen() bool {
-	if spm.port != nil {
+	if spm.port != nil && !spm.closed.Load() {
 		return true
 	}
 
@@ -80,7 +107,8 @@ func (spm *SerialPortManager) IsOpen() bool {
 }
 
 func (spm *SerialPortManager) Close() error {
-	if spm.port != nil {
+	// 设备断开时已经关闭
+	if spm.port != nil && !spm.closed.Swap(true) {
 		return spm.port.Close()
 	}
 
@@ -196,11 +224,8 @@ func (spm *SerialPortManager) ReadExactly(n int) ([]byte, error) {
 	for totalRead < n {
 		bytesRead, err := spm.port.Read(buf[totalRead:])
 		if err != nil {
-			if err == io.EOF {
-				break
-			}
-
-			return nil, err
+			// 设备断开时返回包装了 ErrDisconnected 的错误和已读取的数据
+			return buf[:totalRead], err
 		}
 
 		if bytesRead == 0 {
@@ -234,10 +259,6 @@ func (spm *SerialPortManager) ReadUntil(delimiter []byte) ([]byte, error) {
 	for {
 		n, err := spm.port.Read(buf)
 		if err != nil {
-			if err == io.EOF {
-				break
-			}
-
 			return nil, err
 		}
 
@@ -259,8 +280,110 @@ func (spm *SerialPortManager) ReadUntil(delimiter []byte) ([]byte, error) {
 			}
 		}
 	}
+}
 
-	return result.Bytes(), nil
+// ReadExactlyContext 读取精确的字节数，context 取消时立即返回
+// 连续 Timeout 时间没有数据视为超时，返回已读取的数据
+func (spm *SerialPortManager) ReadExactlyContext(ctx context.Context, n int) ([]byte, error) {
+	if n <= 0 {
+		return nil, errors.New("bytes to read must be greater than 0")
+	}
+
+	if spm.port == nil || !spm.IsOpen() {
+		return nil, errors.New("port is not open")
+	}
+
+	defer spm.port.SetReadTimeout(spm.Timeout)
+
+	buf := make([]byte, n)
+	totalRead := 0
+
+	for totalRead < n {
+		bytesRead, err := spm.readContext(ctx, buf[totalRead:])
+		if err != nil {
+			return buf[:totalRead], err
+		}
+
+		if bytesRead == 0 {
+			// 超时或没有数据
+			return buf[:totalRead], errors.New("read timeout or no data")
+		}
+
+		totalRead += bytesRead
+	}
+
+	return buf, nil
+}
+
+// ReadUntilContext 读取数据直到遇到指定的分隔符，context 取消时立即返回
+// 连续 Timeout 时间没有数据视为超时
+func (spm *SerialPortManager) ReadUntilContext(ctx context.Context, delimiter []byte) ([]byte, error) {
+	if len(delimiter) == 0 {
+		return nil, errors.New("delimiter cannot be empty")
+	}
+
+	if spm.port == nil || !spm.IsOpen() {
+		return nil, errors.New("port is not open")
+	}
+
+	defer spm.port.SetReadTimeout(spm.Timeout)
+
+	var result bytes.Buffer
+	buf := make([]byte, 1)
+
+	for {
+		// 逐字节读取，避免读走分隔符之后的数据
+		n, err := spm.readContext(ctx, buf)
+		if err != nil {
+			return nil, err
+		}
+
+		if n == 0 {
+			// 超时或没有数据
+			return nil, errors.New("read timeout or no data.")
+		}
+
+		result.WriteByte(buf[0])
+		if bytes.HasSuffix(result.Bytes(), delimiter) {
+			data := result.Bytes()
+			return data[:len(data)-len(delimiter)], nil
+		}
+	}
+}
+
+// readContext 以短超时轮询读取，直到读到数据、context 取消或连续 Timeout 时间没有数据（返回 0, nil）
+// 调用方负责在结束后恢复串口读超时
+func (spm *SerialPortManager) readContext(ctx context.Context, buf []byte) (int, error) {
+	timeout := spm.Timeout
+	if timeout <= 0 {
+		timeout = 2 * time.Second
+	}
+	deadline := time.Now().Add(timeout)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		wait := min(time.Until(deadline), contextPollInterval)
+		if wait <= 0 {
+			return 0, nil
+		}
+
+		err := spm.port.SetReadTimeout(wait)
+		if err != nil {
+			return 0, err
+		}
+
+		n, err := spm.port.Read(buf)
+		if err != nil {
+			return 0, err
+		}
+
+		if n > 0 {
+			return n, nil
+		}
+	}
 }
 
 // ReadWithTimeout 在指定时间内读取数据
@@ -269,9 +392,8 @@ func (spm *SerialPortManager) ReadWithTimeout(timeout time.Duration) ([]byte, er
 		return nil, errors.New("port is not open")
 	}
 
-	// 保存原始超时设置
-	originalTimeout := 3 * time.Second
-	defer spm.port.SetReadTimeout(originalTimeout)
+	// 读取结束后恢复原始超时设置
+	defer spm.port.SetReadTimeout(spm.Timeout)
 
 	// 设置新的超时
 	spm.port.SetReadTimeout(timeout)
@@ -281,11 +403,8 @@ func (spm *SerialPortManager) ReadWithTimeout(timeout time.Duration) ([]byte, er
 	for {
 		n, err := spm.port.Read(buf)
 		if err != nil {
-			if err == io.EOF {
-				break
-			}
-
-			return nil, err
+			// 设备断开时返回包装了 ErrDisconnected 的错误和已读取的数据
+			return result.Bytes(), err
 		}
 		if n > 0 {
 			result.Write(buf[:n])
@@ -314,11 +433,8 @@ func (spm *SerialPortManager) ReadAll(maxSize int) ([]byte, error) {
 	for result.Len() < maxSize {
 		n, err := spm.port.Read(buf)
 		if err != nil {
-			if err == io.EOF {
-				break
-			}
-
-			return nil, err
+			// 设备断开时返回包装了 ErrDisconnected 的错误和已读取的数据
+			return result.Bytes(), err
 		}
 
 		if n > 0 {
@@ -333,11 +449,16 @@ func (spm *SerialPortManager) ReadAll(maxSize int) ([]byte, error) {
 }
 
 func (spm *SerialPortManager) Write(p []byte) error {
+	return spm.WriteContext(context.Background(), p)
+}
+
+// WriteContext 写入全部数据，context 取消时停止写入剩余数据
+func (spm *SerialPortManager) WriteContext(ctx context.Context, p []byte) error {
 	if len(p) == 0 {
 		return errors.New("invalid data length for write")
 	}
 
-	if spm.port == nil {
+	if !spm.IsOpen() {
 		return errors.New("port is not open")
 	}
 
@@ -347,6 +468,10 @@ func (spm *SerialPortManager) Write(p []byte) error {
 	writeTimeout := 5 * time.Second
 
 	for totalWritten < len(p) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		// 检查是否超时
 		if time.Since(startTime) > writeTimeout {
 			return errors.New("write timeout: unable to write all data")
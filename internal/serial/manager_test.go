@@ -1,7 +1,11 @@
 package serial
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"go.bug.st/serial"
 )
@@ -83,6 +87,187 @@ func TestSerialPortManager_Open_InvalidPort(t *testing.T) {
 	}
 }
 
+// fakeTransport 内存传输通道，用于验证 SerialPortManager 与具体后端解耦
+type fakeTransport struct {
+	mode    *serial.Mode
+	timeout time.Duration
+	input   bytes.Buffer
+	output  bytes.Buffer
+	closed  bool
+	readErr error // 不为空时读完 input 后 Read 返回该错误，用于模拟设备断开
+}
+
+func (f *fakeTransport) Read(p []byte) (int, error) {
+	if f.input.Len() == 0 {
+		return 0, f.readErr
+	}
+	return f.input.Read(p)
+}
+
+func (f *fakeTransport) Write(p []byte) (int, error) { return f.output.Write(p) }
+func (f *fakeTransport) Close() error                { f.closed = true; return nil }
+func (f *fakeTransport) Drain() error                { return nil }
+
+func (f *fakeTransport) SetMode(mode *serial.Mode) error {
+	f.mode = mode
+	return nil
+}
+
+func (f *fakeTransport) SetReadTimeout(timeout time.Duration) error {
+	f.timeout = timeout
+	return nil
+}
+
+func TestSerialPortManager_Open_CustomOpener(t *testing.T) {
+	fake := &fakeTransport{}
+	var openedName string
+	var openedMode *serial.Mode
+
+	spm := &SerialPortManager{
+		PortName: "fake0",
+		BaudRate: 9600,
+		Opener: func(portName string, mode *serial.Mode) (Transport, error) {
+			openedName = portName
+			openedMode = mode
+			return fake, nil
+		},
+	}
+
+	if err := spm.Open(); err != nil {
+		t.Fatalf("Open with custom opener failed: %v", err)
+	}
+	defer spm.Close()
+
+	if openedName != "fake0" {
+		t.Errorf("Expected opener to receive port name fake0, got %s", openedName)
+	}
+	if openedMode == nil || openedMode.BaudRate != 9600 {
+		t.Errorf("Expected opener to receive baud rate 9600, got %+v", openedMode)
+	}
+	if fake.timeout != 2*time.Second {
+		t.Errorf("Expected default read timeout 2s, got %v", fake.timeout)
+	}
+
+	// 写入和按分隔符读取都应经过传输通道
+	if err := spm.Write([]byte("sendme")); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if fake.output.String() != "sendme" {
+		t.Errorf("Expected transport to receive 'sendme', got %q", fake.output.String())
+	}
+
+	fake.input.Write([]byte{0x66, 0x02, 0xFF, 0xFF, 0xFF})
+	data, err := spm.ReadUntil([]byte{0xFF, 0xFF, 0xFF})
+	if err != nil {
+		t.Fatalf("ReadUntil failed: %v", err)
+	}
+	if !bytes.Equal(data, []byte{0x66, 0x02}) {
+		t.Errorf("Expected 66 02, got % X", data)
+	}
+
+	// 修改波特率应下发到传输通道
+	if err := spm.SetBaudRate(921600); err != nil {
+		t.Fatalf("SetBaudRate failed: %v", err)
+	}
+	if fake.mode == nil || fake.mode.BaudRate != 921600 {
+		t.Errorf("Expected transport mode baud rate 921600, got %+v", fake.mode)
+	}
+
+	if err := spm.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if !fake.closed {
+		t.Error("Expected transport to be closed")
+	}
+}
+
+func TestSerialPortManager_Open_OpenerError(t *testing.T) {
+	spm := &SerialPortManager{
+		PortName: "fake0",
+		Opener: func(portName string, mode *serial.Mode) (Transport, error) {
+			return nil, errors.New("dial failed")
+		},
+	}
+
+	err := spm.Open()
+	if err == nil || err.Error() != "dial failed" {
+		t.Errorf("Expected opener error to be returned, got %v", err)
+	}
+	if spm.IsOpen() {
+		t.Error("Expected port to stay closed after opener error")
+	}
+}
+
+func TestSerialPortManager_ReadUntilContext(t *testing.T) {
+	fake := &fakeTransport{}
+	spm := &SerialPortManager{
+		PortName: "fake0",
+		Timeout:  5 * time.Second,
+		Opener: func(portName string, mode *serial.Mode) (Transport, error) {
+			return fake, nil
+		},
+	}
+	if err := spm.Open(); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer spm.Close()
+
+	fake.input.Write([]byte{0x71, 0x01, 0xFF, 0xFF, 0xFF, 0x66})
+	data, err := spm.ReadUntilContext(context.Background(), []byte{0xFF, 0xFF, 0xFF})
+	if err != nil {
+		t.Fatalf("ReadUntilContext failed: %v", err)
+	}
+	if !bytes.Equal(data, []byte{0x71, 0x01}) {
+		t.Errorf("Expected 71 01, got % X", data)
+	}
+	if fake.input.Len() != 1 {
+		t.Errorf("Expected data after delimiter to remain unread, got %d bytes", fake.input.Len())
+	}
+
+	// 没有数据时 context 超时先于串口超时返回
+	fake.input.Reset()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = spm.ReadUntilContext(ctx, []byte{0xFF, 0xFF, 0xFF})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("Expected ReadUntilContext to return at the context deadline")
+	}
+
+	// 结束后恢复原始读超时
+	if fake.timeout != 5*time.Second {
+		t.Errorf("Expected read timeout restored to 5s, got %v", fake.timeout)
+	}
+}
+
+func TestSerialPortManager_WriteContext_Canceled(t *testing.T) {
+	fake := &fakeTransport{}
+	spm := &SerialPortManager{
+		PortName: "fake0",
+		Opener: func(portName string, mode *serial.Mode) (Transport, error) {
+			return fake, nil
+		},
+	}
+	if err := spm.Open(); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer spm.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := spm.WriteContext(ctx, []byte("page 0")); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if fake.output.Len() != 0 {
+		t.Errorf("Expected nothing written after cancellation, got %q", fake.output.String())
+	}
+}
+
 func TestSerialPortManager_IsOpen(t *testing.T) {
 	spm := &SerialPortManager{
 		PortName: "/dev/null",
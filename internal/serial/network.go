@@ -0,0 +1,359 @@
+package serial
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"go.bug.st/serial"
+)
+
+// 网络端口名前缀
+const (
+	SchemeTCP     = "tcp"     // tcp://host:port 透明 TCP 串口服务器，无法远程修改串口参数
+	SchemeRFC2217 = "rfc2217" // rfc2217://host:port 支持远程修改波特率等串口参数
+)
+
+const (
+	networkDialTimeout = 5 * time.Second
+	comPortReplyWait   = 2 * time.Second
+)
+
+var (
+	ErrPortClosed         = errors.New("port is closed")
+	ErrModeNotSupported   = errors.New("transport does not support changing serial mode")
+	ErrComPortUnsupported = errors.New("remote server does not support rfc2217 com port control")
+)
+
+// IsNetworkPort 判断端口名是否为网络地址（tcp:// 或 rfc2217://）
+func IsNetworkPort(portName string) bool {
+	scheme, _, ok := strings.Cut(portName, "://")
+	if !ok {
+		return false
+	}
+
+	scheme = strings.ToLower(scheme)
+	return scheme == SchemeTCP || scheme == SchemeRFC2217
+}
+
+// OpenPort 按端口名打开传输通道：网络地址使用 OpenNetworkPort，其余使用系统串口
+func OpenPort(portName string, mode *serial.Mode) (Transport, error) {
+	if IsNetworkPort(portName) {
+		return OpenNetworkPort(portName, mode)
+	}
+
+	return OpenSerialPort(portName, mode)
+}
+
+// OpenNetworkPort 连接串口服务器，地址格式为 tcp://host:port 或 rfc2217://host:port
+// rfc2217 连接后按 mode 设置远端串口参数，之后的 SetMode 也会下发到远端
+func OpenNetworkPort(portName string, mode *serial.Mode) (Transport, error) {
+	u, err := url.Parse(portName)
+	if err != nil {
+		return nil, fmt.Errorf("invalid network port %s: %w", portName, err)
+	}
+	if u.Host == "" || u.Port() == "" {
+		return nil, fmt.Errorf("invalid network port %s: host and port are required", portName)
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	if scheme != SchemeTCP && scheme != SchemeRFC2217 {
+		return nil, fmt.Errorf("unsupported network port scheme: %s", u.Scheme)
+	}
+
+	conn, err := net.DialTimeout("tcp", u.Host, networkDialTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	port := newNetPort(conn, scheme == SchemeRFC2217)
+	port.mode = *mode
+	if port.telnet {
+		err = port.negotiate(mode)
+		if err != nil {
+			port.Close()
+			return nil, err
+		}
+	}
+
+	return port, nil
+}
+
+// netPort 通过 TCP 连接访问的串口，实现 Transport
+// 后台协程持续读取连接，rfc2217 模式下同时处理 Telnet 协商和串口参数应答
+type netPort struct {
+	conn    net.Conn
+	telnet  bool
+	writeMu sync.Mutex
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	buf     []byte // 尚未被读取的数据
+	err     error  // 读取协程退出原因
+	closed  bool
+	timeout time.Duration
+	mode    serial.Mode // 当前串口参数
+
+	// rfc2217
+	decoder telnetDecoder
+	replies chan telnetCommand // 服务端的串口参数应答
+	refused bool               // 服务端拒绝串口控制选项
+}
+
+func newNetPort(conn net.Conn, telnet bool) *netPort {
+	p := &netPort{
+		conn:    conn,
+		telnet:  telnet,
+		timeout: serial.NoTimeout,
+		replies: make(chan telnetCommand, 16),
+	}
+	p.cond = sync.NewCond(&p.mu)
+
+	go p.readLoop()
+
+	return p
+}
+
+func (p *netPort) readLoop() {
+	buf := make([]byte, 4096)
+	for {
+		n, err := p.conn.Read(buf)
+		if n > 0 {
+			data := buf[:n]
+			if p.telnet {
+				var commands []telnetCommand
+				data, commands = p.decoder.decode(data)
+				for _, command := range commands {
+					p.handleCommand(command)
+				}
+			}
+			p.push(data)
+		}
+
+		if err != nil {
+			p.mu.Lock()
+			p.err = err
+			p.cond.Broadcast()
+			p.mu.Unlock()
+			return
+		}
+	}
+}
+
+func (p *netPort) push(data []byte) {
+	if len(data) == 0 {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.buf = append(p.buf, data...)
+	p.cond.Broadcast()
+}
+
+// handleCommand 处理服务端发来的 Telnet 指令
+func (p *netPort) handleCommand(command telnetCommand) {
+	supported := command.Option == telnetOptBinary ||
+		command.Option == telnetOptSGA ||
+		command.Option == telnetOptComPort
+
+	switch command.Verb {
+	case telnetDO:
+		// 连接时已主动声明 WILL BINARY/COM-PORT，这里只拒绝其他选项
+		if !supported {
+			_ = p.writeRaw(telnetNegotiate(telnetWONT, command.Option))
+		}
+	case telnetWILL:
+		if command.Option == telnetOptSGA {
+			_ = p.writeRaw(telnetNegotiate(telnetDO, command.Option))
+		} else if !supported {
+			_ = p.writeRaw(telnetNegotiate(telnetDONT, command.Option))
+		}
+	case telnetDONT:
+		if command.Option == telnetOptComPort {
+			p.mu.Lock()
+			p.refused = true
+			p.mu.Unlock()
+
+			select {
+			case p.replies <- command:
+			default:
+			}
+		}
+	case telnetSB:
+		if command.Option == telnetOptComPort && len(command.Payload) > 0 && command.Payload[0] > comPortReplyOffset {
+			select {
+			case p.replies <- command:
+			default:
+			}
+		}
+	}
+}
+
+// negotiate 声明二进制传输和串口控制选项，并设置远端串口参数
+func (p *netPort) negotiate(mode *serial.Mode) error {
+	var out []byte
+	out = append(out, telnetNegotiate(telnetWILL, telnetOptBinary)...)
+	out = append(out, telnetNegotiate(telnetDO, telnetOptBinary)...)
+	out = append(out, telnetNegotiate(telnetWILL, telnetOptComPort)...)
+
+	err := p.writeRaw(out)
+	if err != nil {
+		return err
+	}
+
+	return p.SetMode(mode)
+}
+
+func (p *netPort) writeRaw(data []byte) error {
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+
+	_, err := p.conn.Write(data)
+	return err
+}
+
+// Read 读取数据，超时后返回 0 字节且不返回错误
+func (p *netPort) Read(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var deadline time.Time
+	if p.timeout >= 0 {
+		deadline = time.Now().Add(p.timeout)
+	}
+
+	for len(p.buf) == 0 && p.err == nil && !p.closed {
+		if p.timeout < 0 {
+			p.cond.Wait()
+			continue
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return 0, nil
+		}
+
+		// sync.Cond 不支持超时等待，借助定时器唤醒
+		timer := time.AfterFunc(remaining, func() {
+			p.mu.Lock()
+			p.cond.Broadcast()
+			p.mu.Unlock()
+		})
+		p.cond.Wait()
+		timer.Stop()
+	}
+
+	if len(p.buf) > 0 {
+		n := copy(b, p.buf)
+		p.buf = p.buf[n:]
+		return n, nil
+	}
+
+	if p.closed {
+		return 0, ErrPortClosed
+	}
+
+	return 0, p.err
+}
+
+func (p *netPort) Write(b []byte) (int, error) {
+	data := b
+	if p.telnet {
+		data = escapeIAC(b)
+	}
+
+	err := p.writeRaw(data)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(b), nil
+}
+
+func (p *netPort) Close() error {
+	p.mu.Lock()
+	p.closed = true
+	p.cond.Broadcast()
+	p.mu.Unlock()
+
+	return p.conn.Close()
+}
+
+// SetMode rfc2217 模式下设置远端串口参数并等待服务端确认；tcp 模式无法修改串口参数
+func (p *netPort) SetMode(mode *serial.Mode) error {
+	if !p.telnet {
+		// 参数不变时视为成功，便于升级结束后恢复波特率
+		if *mode == p.mode {
+			return nil
+		}
+		return ErrModeNotSupported
+	}
+
+	p.mu.Lock()
+	refused := p.refused
+	p.mu.Unlock()
+	if refused {
+		return ErrComPortUnsupported
+	}
+
+	commands, err := comPortModeCommands(mode)
+	if err != nil {
+		return err
+	}
+
+	// 清理之前遗留的应答
+	for len(p.replies) > 0 {
+		<-p.replies
+	}
+
+	err = p.writeRaw(commands)
+	if err != nil {
+		return err
+	}
+
+	// 等待四项参数全部确认
+	pending := map[byte]bool{
+		comPortSetBaudRate + comPortReplyOffset: true,
+		comPortSetDataSize + comPortReplyOffset: true,
+		comPortSetParity + comPortReplyOffset:   true,
+		comPortSetStopSize + comPortReplyOffset: true,
+	}
+	timer := time.NewTimer(comPortReplyWait)
+	defer timer.Stop()
+
+	for len(pending) > 0 {
+		select {
+		case reply := <-p.replies:
+			if reply.Verb == telnetDONT {
+				return ErrComPortUnsupported
+			}
+			delete(pending, reply.Payload[0])
+		case <-timer.C:
+			return errors.New("timeout waiting for rfc2217 com port reply")
+		}
+	}
+
+	p.mode = *mode
+	return nil
+}
+
+func (p *netPort) SetReadTimeout(timeout time.Duration) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.timeout = timeout
+	p.cond.Broadcast()
+
+	return nil
+}
+
+// Drain 网络连接写入后即交给系统发送，无需等待
+func (p *netPort) Drain() error {
+	return nil
+}
@@ -0,0 +1,299 @@
+package serial
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"go.bug.st/serial"
+)
+
+// loopbackServer 本地串口服务器替身：回显收到的数据，rfc2217 模式下应答串口参数设置
+type loopbackServer struct {
+	listener net.Listener
+	telnet   bool
+	refuse   bool // 拒绝串口控制选项
+
+	mu       sync.Mutex
+	baudRate int
+	dataBits int
+	received []byte
+}
+
+func newLoopbackServer(t *testing.T, telnet, refuse bool) *loopbackServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+
+	s := &loopbackServer{listener: listener, telnet: telnet, refuse: refuse}
+	t.Cleanup(func() { listener.Close() })
+
+	go s.serve()
+
+	return s
+}
+
+func (s *loopbackServer) addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *loopbackServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *loopbackServer) handle(conn net.Conn) {
+	defer conn.Close()
+
+	var decoder telnetDecoder
+	buf := make([]byte, 4096)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return
+		}
+
+		data := buf[:n]
+		if !s.telnet {
+			s.record(data)
+			conn.Write(data)
+			continue
+		}
+
+		data, commands := decoder.decode(data)
+		for _, command := range commands {
+			s.handleCommand(conn, command)
+		}
+		if len(data) > 0 {
+			s.record(data)
+			conn.Write(escapeIAC(data))
+		}
+	}
+}
+
+func (s *loopbackServer) handleCommand(conn net.Conn, command telnetCommand) {
+	switch command.Verb {
+	case telnetWILL:
+		if command.Option == telnetOptComPort && s.refuse {
+			conn.Write(telnetNegotiate(telnetDONT, command.Option))
+			return
+		}
+		conn.Write(telnetNegotiate(telnetDO, command.Option))
+	case telnetSB:
+		if command.Option != telnetOptComPort || s.refuse {
+			return
+		}
+
+		s.mu.Lock()
+		switch command.Payload[0] {
+		case comPortSetBaudRate:
+			s.baudRate = int(binary.BigEndian.Uint32(command.Payload[1:]))
+		case comPortSetDataSize:
+			s.dataBits = int(command.Payload[1])
+		}
+		s.mu.Unlock()
+
+		reply := append([]byte{command.Payload[0] + comPortReplyOffset}, command.Payload[1:]...)
+		conn.Write(telnetSubnegotiate(telnetOptComPort, reply...))
+	}
+}
+
+func (s *loopbackServer) record(data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.received = append(s.received, data...)
+}
+
+func (s *loopbackServer) state() (int, int, []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.baudRate, s.dataBits, bytes.Clone(s.received)
+}
+
+func readFull(t *testing.T, port Transport, n int) []byte {
+	t.Helper()
+
+	port.SetReadTimeout(time.Second)
+
+	var out []byte
+	buf := make([]byte, n)
+	for len(out) < n {
+		m, err := port.Read(buf[:n-len(out)])
+		if err != nil {
+			t.Fatalf("Read failed: %v", err)
+		}
+		if m == 0 {
+			t.Fatalf("Read timed out after %d bytes", len(out))
+		}
+		out = append(out, buf[:m]...)
+	}
+
+	return out
+}
+
+func TestIsNetworkPort(t *testing.T) {
+	testCases := map[string]bool{
+		"tcp://10.0.0.5:4001":     true,
+		"TCP://10.0.0.5:4001":     true,
+		"rfc2217://host:4001":     true,
+		"/dev/ttyUSB0":            false,
+		"COM3":                    false,
+		"udp://10.0.0.5:4001":     false,
+		"tcp:10.0.0.5:4001":       false,
+		"rfc2217://[::1]:4001":    true,
+		"\\\\.\\COM10":            false,
+		"tcp://":                  true,
+		"/dev/serial/by-id/usb-1": false,
+	}
+
+	for portName, expected := range testCases {
+		if got := IsNetworkPort(portName); got != expected {
+			t.Errorf("IsNetworkPort(%q) = %v, expected %v", portName, got, expected)
+		}
+	}
+}
+
+func TestOpenNetworkPort_InvalidAddress(t *testing.T) {
+	mode := &serial.Mode{BaudRate: 115200}
+
+	for _, portName := range []string{"tcp://", "tcp://host", "udp://host:1"} {
+		if _, err := OpenNetworkPort(portName, mode); err == nil {
+			t.Errorf("Expected error for %q", portName)
+		}
+	}
+}
+
+func TestNetworkPort_TCP(t *testing.T) {
+	server := newLoopbackServer(t, false, false)
+	mode := &serial.Mode{BaudRate: 115200, DataBits: 8}
+
+	port, err := OpenPort("tcp://"+server.addr(), mode)
+	if err != nil {
+		t.Fatalf("OpenPort failed: %v", err)
+	}
+	defer port.Close()
+
+	frame := []byte{0x71, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}
+	if _, err := port.Write(frame); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if got := readFull(t, port, len(frame)); !bytes.Equal(got, frame) {
+		t.Errorf("Expected echo % X, got % X", frame, got)
+	}
+
+	// 透明 TCP 无法远程修改波特率，参数不变时视为成功
+	if err := port.SetMode(&serial.Mode{BaudRate: 921600, DataBits: 8}); !errors.Is(err, ErrModeNotSupported) {
+		t.Errorf("Expected ErrModeNotSupported, got %v", err)
+	}
+	if err := port.SetMode(mode); err != nil {
+		t.Errorf("Expected unchanged mode to succeed, got %v", err)
+	}
+}
+
+func TestNetworkPort_RFC2217(t *testing.T) {
+	server := newLoopbackServer(t, true, false)
+
+	port, err := OpenPort("rfc2217://"+server.addr(), &serial.Mode{BaudRate: 115200, DataBits: 8})
+	if err != nil {
+		t.Fatalf("OpenPort failed: %v", err)
+	}
+	defer port.Close()
+
+	if baudRate, dataBits, _ := server.state(); baudRate != 115200 || dataBits != 8 {
+		t.Errorf("Expected remote 115200/8 after open, got %d/%d", baudRate, dataBits)
+	}
+
+	// 0xFF 在链路上转义，两端收到的都是原始数据
+	frame := []byte{0x01, 0xFF, 0xFF, 0xFF}
+	if _, err := port.Write(frame); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if got := readFull(t, port, len(frame)); !bytes.Equal(got, frame) {
+		t.Errorf("Expected echo % X, got % X", frame, got)
+	}
+	if _, _, received := server.state(); !bytes.Equal(received, frame) {
+		t.Errorf("Expected server to receive % X, got % X", frame, received)
+	}
+
+	if err := port.SetMode(&serial.Mode{BaudRate: 921600, DataBits: 8}); err != nil {
+		t.Fatalf("SetMode failed: %v", err)
+	}
+	if baudRate, _, _ := server.state(); baudRate != 921600 {
+		t.Errorf("Expected remote baud rate 921600, got %d", baudRate)
+	}
+}
+
+func TestNetworkPort_RFC2217_Refused(t *testing.T) {
+	server := newLoopbackServer(t, true, true)
+
+	_, err := OpenPort("rfc2217://"+server.addr(), &serial.Mode{BaudRate: 115200})
+	if !errors.Is(err, ErrComPortUnsupported) {
+		t.Errorf("Expected ErrComPortUnsupported, got %v", err)
+	}
+}
+
+func TestNetworkPort_ReadTimeoutAndClose(t *testing.T) {
+	server := newLoopbackServer(t, false, false)
+
+	port, err := OpenNetworkPort("tcp://"+server.addr(), &serial.Mode{BaudRate: 115200})
+	if err != nil {
+		t.Fatalf("OpenNetworkPort failed: %v", err)
+	}
+
+	port.SetReadTimeout(20 * time.Millisecond)
+	n, err := port.Read(make([]byte, 1))
+	if n != 0 || err != nil {
+		t.Errorf("Expected timeout read to return 0, nil; got %d, %v", n, err)
+	}
+
+	port.Close()
+	if _, err := port.Read(make([]byte, 1)); err == nil {
+		t.Error("Expected error when reading closed port")
+	}
+}
+
+func TestSerialPortManager_NetworkPort(t *testing.T) {
+	server := newLoopbackServer(t, true, false)
+
+	spm := &SerialPortManager{
+		PortName: "rfc2217://" + server.addr(),
+		BaudRate: 115200,
+		Timeout:  time.Second,
+	}
+	if err := spm.Open(); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer spm.Close()
+
+	if err := spm.Write([]byte{0x66, 0x01, 0xFF, 0xFF, 0xFF}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	data, err := spm.ReadUntil([]byte{0xFF, 0xFF, 0xFF})
+	if err != nil {
+		t.Fatalf("ReadUntil failed: %v", err)
+	}
+	if !bytes.Equal(data, []byte{0x66, 0x01}) {
+		t.Errorf("Expected 66 01, got % X", data)
+	}
+
+	if err := spm.SetBaudRate(921600); err != nil {
+		t.Fatalf("SetBaudRate failed: %v", err)
+	}
+	if baudRate, _, _ := server.state(); baudRate != 921600 {
+		t.Errorf("Expected remote baud rate 921600, got %d", baudRate)
+	}
+}
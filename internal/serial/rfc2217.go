@@ -0,0 +1,223 @@
+package serial
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"go.bug.st/serial"
+)
+
+// Telnet 协议字节（RFC 854）
+const (
+	telnetSE   = 240 // 子协商结束
+	telnetSB   = 250 // 子协商开始
+	telnetWILL = 251
+	telnetWONT = 252
+	telnetDO   = 253
+	telnetDONT = 254
+	telnetIAC  = 255
+)
+
+// Telnet 选项
+const (
+	telnetOptBinary  = 0  // 二进制传输（RFC 856）
+	telnetOptSGA     = 3  // 抑制继续进行（RFC 858）
+	telnetOptComPort = 44 // 串口控制（RFC 2217）
+)
+
+// RFC 2217 串口控制指令，服务端应答为指令值加 100
+const (
+	comPortSetBaudRate = 1
+	comPortSetDataSize = 2
+	comPortSetParity   = 3
+	comPortSetStopSize = 4
+	comPortReplyOffset = 100
+)
+
+// telnetCommand 数据流中的一条 Telnet 指令
+type telnetCommand struct {
+	Verb    byte   // telnetWILL/WONT/DO/DONT/SB
+	Option  byte   // 选项
+	Payload []byte // 子协商内容（不含选项字节），已去除 IAC 转义
+}
+
+// telnetDecoder 从 Telnet 数据流中分离数据和指令，可跨多次读取保持解析状态
+type telnetDecoder struct {
+	state byte // 0 普通数据，telnetIAC 收到 IAC，telnetWILL 等收到协商动词，telnetSB 子协商中
+	verb  byte
+	sb    []byte
+	sbIAC bool // 子协商中收到 IAC
+}
+
+// decode 解析一段数据，返回其中的用户数据和 Telnet 指令
+func (d *telnetDecoder) decode(in []byte) ([]byte, []telnetCommand) {
+	var data []byte
+	var commands []telnetCommand
+
+	for _, b := range in {
+		switch d.state {
+		case 0:
+			if b == telnetIAC {
+				d.state = telnetIAC
+				continue
+			}
+			data = append(data, b)
+		case telnetIAC:
+			switch b {
+			case telnetIAC:
+				// IAC IAC 表示数据 0xFF
+				data = append(data, b)
+				d.state = 0
+			case telnetWILL, telnetWONT, telnetDO, telnetDONT:
+				d.verb = b
+				d.state = telnetWILL
+			case telnetSB:
+				d.sb = d.sb[:0]
+				d.sbIAC = false
+				d.state = telnetSB
+			default:
+				// 其他 Telnet 指令（NOP、AYT 等）忽略
+				d.state = 0
+			}
+		case telnetWILL:
+			commands = append(commands, telnetCommand{Verb: d.verb, Option: b})
+			d.state = 0
+		case telnetSB:
+			if d.sbIAC {
+				d.sbIAC = false
+				if b == telnetSE {
+					if len(d.sb) > 0 {
+						commands = append(commands, telnetCommand{
+							Verb:    telnetSB,
+							Option:  d.sb[0],
+							Payload: append([]byte(nil), d.sb[1:]...),
+						})
+					}
+					d.state = 0
+					continue
+				}
+				// IAC IAC 为转义的 0xFF
+			} else if b == telnetIAC {
+				d.sbIAC = true
+				continue
+			}
+			d.sb = append(d.sb, b)
+		}
+	}
+
+	return data, commands
+}
+
+// escapeIAC 将数据中的 0xFF 转义为 IAC IAC
+func escapeIAC(p []byte) []byte {
+	out := make([]byte, 0, len(p)+8)
+	for _, b := range p {
+		if b == telnetIAC {
+			out = append(out, telnetIAC)
+		}
+		out = append(out, b)
+	}
+
+	return out
+}
+
+// telnetNegotiate 生成一条协商指令
+func telnetNegotiate(verb, option byte) []byte {
+	return []byte{telnetIAC, verb, option}
+}
+
+// telnetSubnegotiate 生成一条子协商指令，内容中的 0xFF 会被转义
+func telnetSubnegotiate(option byte, payload ...byte) []byte {
+	out := []byte{telnetIAC, telnetSB, option}
+	out = append(out, escapeIAC(payload)...)
+	return append(out, telnetIAC, telnetSE)
+}
+
+// comPortModeCommands 生成设置串口参数的 RFC 2217 子协商指令
+func comPortModeCommands(mode *serial.Mode) ([]byte, error) {
+	parity, err := encodeParity(mode.Parity)
+	if err != nil {
+		return nil, err
+	}
+	stopBits, err := encodeStopBits(mode.StopBits)
+	if err != nil {
+		return nil, err
+	}
+
+	dataBits := mode.DataBits
+	if dataBits == 0 {
+		dataBits = 8
+	}
+
+	var out []byte
+	out = append(out, telnetSubnegotiate(telnetOptComPort, append([]byte{comPortSetBaudRate}, binary.BigEndian.AppendUint32(nil, uint32(mode.BaudRate))...)...)...)
+	out = append(out, telnetSubnegotiate(telnetOptComPort, comPortSetDataSize, byte(dataBits))...)
+	out = append(out, telnetSubnegotiate(telnetOptComPort, comPortSetParity, parity)...)
+	out = append(out, telnetSubnegotiate(telnetOptComPort, comPortSetStopSize, stopBits)...)
+
+	return out, nil
+}
+
+// RFC 2217 校验位取值：1 无校验，2 奇校验，3 偶校验，4 Mark，5 Space
+func encodeParity(parity serial.Parity) (byte, error) {
+	switch parity {
+	case serial.NoParity:
+		return 1, nil
+	case serial.OddParity:
+		return 2, nil
+	case serial.EvenParity:
+		return 3, nil
+	case serial.MarkParity:
+		return 4, nil
+	case serial.SpaceParity:
+		return 5, nil
+	}
+
+	return 0, fmt.Errorf("unsupported parity: %d", parity)
+}
+
+// RFC 2217 停止位取值：1 一位，2 两位，3 一位半
+func encodeStopBits(stopBits serial.StopBits) (byte, error) {
+	switch stopBits {
+	case serial.OneStopBit:
+		return 1, nil
+	case serial.TwoStopBits:
+		return 2, nil
+	case serial.OnePointFiveStopBits:
+		return 3, nil
+	}
+
+	return 0, fmt.Errorf("unsupported stop bits: %d", stopBits)
+}
+
+// decodeParity 将 RFC 2217 校验位取值转换为串口参数
+func decodeParity(value byte) (serial.Parity, error) {
+	switch value {
+	case 1:
+		return serial.NoParity, nil
+	case 2:
+		return serial.OddParity, nil
+	case 3:
+		return serial.EvenParity, nil
+	case 4:
+		return serial.MarkParity, nil
+	case 5:
+		return serial.SpaceParity, nil
+	}
+
+	return 0, fmt.Errorf("unsupported rfc2217 parity: %d", value)
+}
+
+// decodeStopBits 将 RFC 2217 停止位取值转换为串口参数
+func decodeStopBits(value byte) (serial.StopBits, error) {
+	switch value {
+	case 1:
+		return serial.OneStopBit, nil
+	case 2:
+		return serial.TwoStopBits, nil
+	case 3:
+		return serial.OnePointFiveStopBits, nil
+	}
+
+	return 0, fmt.Errorf("unsupported rfc2217 stop bits: %d", value)
+}
@@ -0,0 +1,389 @@
+package serial
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	defaultWriterIdle   = 2 * time.Second
+	serverWriteDeadline = 2 * time.Second
+)
+
+var ErrServerClosed = errors.New("port server closed")
+
+// PortServer 把一个已打开的串口共享到网络上（tjc serve-port）
+// 串口收到的数据广播给所有客户端；同一时刻只有一个客户端可以写入，
+// 写入者断开或空闲超过 WriterIdle 后，其他客户端才能获得写入权，避免多个客户端的指令交错
+// RFC2217 为 true 时按 RFC 2217 与客户端通信，客户端可以远程修改波特率等串口参数
+type PortServer struct {
+	Manager    *SerialPortManager                  // 共享的串口，需要在 Serve 之前打开
+	RFC2217    bool                                // 是否启用 RFC 2217，否则为透明 TCP
+	WriterIdle time.Duration                       // 写入者空闲多久后释放写入权，默认 2 秒
+	OnClient   func(addr net.Addr, connected bool) // 客户端连接和断开时回调，可为空
+
+	mu        sync.Mutex
+	cond      *sync.Cond
+	listener  net.Listener
+	clients   map[*portClient]struct{}
+	owner     *portClient // 当前写入者
+	lastWrite time.Time
+	closed    bool
+	err       error // 串口读取失败的原因
+
+	modeMu sync.Mutex // 保护串口参数的读取和修改
+}
+
+// portClient 一个网络客户端连接
+type portClient struct {
+	conn    net.Conn
+	writeMu sync.Mutex
+	decoder telnetDecoder
+	closed  bool // 由 PortServer.mu 保护
+}
+
+func (c *portClient) write(data []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	c.conn.SetWriteDeadline(time.Now().Add(serverWriteDeadline))
+	_, err := c.conn.Write(data)
+	return err
+}
+
+// ListenAndServe 监听 TCP 地址并开始服务
+func (s *PortServer) ListenAndServe(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	return s.Serve(listener)
+}
+
+// Serve 在 listener 上接受客户端连接，直到 Close 被调用或串口读取失败
+// 调用 Close 后返回 ErrServerClosed，串口读取失败时返回该错误
+func (s *PortServer) Serve(listener net.Listener) error {
+	if s.Manager == nil || !s.Manager.IsOpen() {
+		listener.Close()
+		return errors.New("port is not open")
+	}
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		listener.Close()
+		return ErrServerClosed
+	}
+	if s.cond == nil {
+		s.cond = sync.NewCond(&s.mu)
+	}
+	s.listener = listener
+	s.clients = make(map[*portClient]struct{})
+	s.mu.Unlock()
+
+	go s.readLoop()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			s.mu.Lock()
+			closed, readErr := s.closed, s.err
+			s.mu.Unlock()
+
+			if readErr != nil {
+				return readErr
+			}
+			if closed {
+				return ErrServerClosed
+			}
+
+			s.Close()
+			return err
+		}
+
+		go s.handle(conn)
+	}
+}
+
+// Close 停止监听并断开所有客户端，串口由调用方关闭
+func (s *PortServer) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
+	for c := range s.clients {
+		c.conn.Close()
+	}
+	if s.cond != nil {
+		s.cond.Broadcast()
+	}
+	if s.listener != nil {
+		return s.listener.Close()
+	}
+
+	return nil
+}
+
+// readLoop 持续读取串口并广播给所有客户端
+func (s *PortServer) readLoop() {
+	for {
+		data, err := s.Manager.Read()
+
+		s.mu.Lock()
+		closed := s.closed
+		s.mu.Unlock()
+		if closed {
+			return
+		}
+
+		if err != nil {
+			s.mu.Lock()
+			s.err = err
+			s.mu.Unlock()
+			s.Close()
+			return
+		}
+
+		if len(data) > 0 {
+			s.broadcast(data)
+		}
+	}
+}
+
+func (s *PortServer) broadcast(data []byte) {
+	if s.RFC2217 {
+		data = escapeIAC(data)
+	}
+
+	s.mu.Lock()
+	clients := make([]*portClient, 0, len(s.clients))
+	for c := range s.clients {
+		clients = append(clients, c)
+	}
+	s.mu.Unlock()
+
+	for _, c := range clients {
+		// 接收过慢的客户端直接断开，避免拖慢其他客户端
+		if err := c.write(data); err != nil {
+			c.conn.Close()
+		}
+	}
+}
+
+func (s *PortServer) handle(conn net.Conn) {
+	c := &portClient{conn: conn}
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		conn.Close()
+		return
+	}
+	s.clients[c] = struct{}{}
+	s.mu.Unlock()
+
+	if s.OnClient != nil {
+		s.OnClient(conn.RemoteAddr(), true)
+	}
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.clients, c)
+		c.closed = true
+		if s.owner == c {
+			s.owner = nil
+		}
+		s.cond.Broadcast()
+		s.mu.Unlock()
+
+		conn.Close()
+
+		if s.OnClient != nil {
+			s.OnClient(conn.RemoteAddr(), false)
+		}
+	}()
+
+	buf := make([]byte, 4096)
+	for {
+		n, err := conn.Read(buf)
+		if n > 0 {
+			data := buf[:n]
+			if s.RFC2217 {
+				var commands []telnetCommand
+				data, commands = c.decoder.decode(data)
+				for _, command := range commands {
+					s.handleCommand(c, command)
+				}
+			}
+
+			if len(data) > 0 {
+				if !s.acquire(c) {
+					return
+				}
+				if err := s.Manager.Write(data); err != nil {
+					return
+				}
+			}
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+// acquire 获取写入权，其他客户端持有且未空闲超时时等待；服务关闭或客户端断开时返回 false
+func (s *PortServer) acquire(c *portClient) bool {
+	idle := s.WriterIdle
+	if idle <= 0 {
+		idle = defaultWriterIdle
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for {
+		if s.closed || c.closed {
+			return false
+		}
+
+		elapsed := time.Since(s.lastWrite)
+		if s.owner == nil || s.owner == c || elapsed >= idle {
+			s.owner = c
+			s.lastWrite = time.Now()
+			return true
+		}
+
+		// sync.Cond 不支持超时等待，借助定时器在空闲超时时唤醒
+		timer := time.AfterFunc(idle-elapsed, func() {
+			s.mu.Lock()
+			s.cond.Broadcast()
+			s.mu.Unlock()
+		})
+		s.cond.Wait()
+		timer.Stop()
+	}
+}
+
+// setMode 参数需要修改时先获得写入权再修改
+// 等待写入权时不持有 modeMu，避免阻塞其他客户端的查询；获得写入权后重新比较，参数可能已被修改
+func (s *PortServer) setMode(c *portClient, changed func() bool, apply func()) {
+	s.modeMu.Lock()
+	need := changed()
+	s.modeMu.Unlock()
+
+	if !need || !s.acquire(c) {
+		return
+	}
+
+	s.modeMu.Lock()
+	defer s.modeMu.Unlock()
+
+	if changed() {
+		apply()
+	}
+}
+
+// currentMode 在 modeMu 保护下读取当前参数
+func (s *PortServer) currentMode(get func() []byte) []byte {
+	s.modeMu.Lock()
+	defer s.modeMu.Unlock()
+
+	return get()
+}
+
+// handleCommand 处理客户端发来的 Telnet 指令
+func (s *PortServer) handleCommand(c *portClient, command telnetCommand) {
+	switch command.Verb {
+	case telnetWILL:
+		if command.Option == telnetOptBinary || command.Option == telnetOptSGA || command.Option == telnetOptComPort {
+			_ = c.write(telnetNegotiate(telnetDO, command.Option))
+		} else {
+			_ = c.write(telnetNegotiate(telnetDONT, command.Option))
+		}
+	case telnetDO:
+		if command.Option == telnetOptBinary || command.Option == telnetOptSGA {
+			_ = c.write(telnetNegotiate(telnetWILL, command.Option))
+		} else {
+			_ = c.write(telnetNegotiate(telnetWONT, command.Option))
+		}
+	case telnetSB:
+		if command.Option == telnetOptComPort && len(command.Payload) > 0 {
+			s.handleComPort(c, command.Payload[0], command.Payload[1:])
+		}
+	}
+}
+
+// handleComPort 处理串口控制指令并应答当前参数
+// 参数值为 0 表示查询；参数与当前相同时无需写入权，修改参数需要先获得写入权
+func (s *PortServer) handleComPort(c *portClient, command byte, value []byte) {
+	var reply []byte
+
+	switch command {
+	case comPortSetBaudRate:
+		if len(value) < 4 {
+			return
+		}
+		baudRate := int(binary.BigEndian.Uint32(value))
+
+		s.setMode(c,
+			func() bool { return baudRate != 0 && baudRate != s.Manager.BaudRate },
+			func() { _ = s.Manager.SetBaudRate(baudRate) },
+		)
+		reply = s.currentMode(func() []byte { return binary.BigEndian.AppendUint32(nil, uint32(s.Manager.BaudRate)) })
+	case comPortSetDataSize:
+		if len(value) < 1 {
+			return
+		}
+
+		s.setMode(c,
+			func() bool { return value[0] != 0 && int(value[0]) != s.Manager.DataBits },
+			func() { _ = s.Manager.SetDataBits(int(value[0])) },
+		)
+		reply = s.currentMode(func() []byte { return []byte{byte(s.Manager.DataBits)} })
+	case comPortSetParity:
+		if len(value) < 1 {
+			return
+		}
+
+		parity, err := decodeParity(value[0])
+		s.setMode(c,
+			func() bool { return err == nil && parity != s.Manager.Parity },
+			func() { _ = s.Manager.SetParity(parity) },
+		)
+		reply = s.currentMode(func() []byte {
+			current, _ := encodeParity(s.Manager.Parity)
+			return []byte{current}
+		})
+	case comPortSetStopSize:
+		if len(value) < 1 {
+			return
+		}
+
+		stopBits, err := decodeStopBits(value[0])
+		s.setMode(c,
+			func() bool { return err == nil && stopBits != s.Manager.StopBits },
+			func() { _ = s.Manager.SetStopBits(stopBits) },
+		)
+		reply = s.currentMode(func() []byte {
+			current, _ := encodeStopBits(s.Manager.StopBits)
+			return []byte{current}
+		})
+	default:
+		// 流控、线路状态掩码等其他指令不做处理，原样确认以兼容常见客户端
+		if command >= comPortReplyOffset {
+			return
+		}
+		reply = value
+	}
+
+	_ = c.write(telnetSubnegotiate(telnetOptComPort, append([]byte{command + comPortReplyOffset}, reply...)...))
+}
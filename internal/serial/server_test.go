@@ -0,0 +1,146 @@
+package serial
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"go.bug.st/serial"
+)
+
+// startPortServer 在本地端口上共享 backend 对应的串口，返回服务地址
+func startPortServer(t *testing.T, backend string, server *PortServer) string {
+	t.Helper()
+
+	spm := &SerialPortManager{PortName: backend, BaudRate: 115200, Timeout: 50 * time.Millisecond}
+	if err := spm.Open(); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	server.Manager = spm
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- server.Serve(listener) }()
+
+	t.Cleanup(func() {
+		server.Close()
+		if err := <-done; !errors.Is(err, ErrServerClosed) {
+			t.Errorf("Expected ErrServerClosed, got %v", err)
+		}
+		spm.Close()
+	})
+
+	return listener.Addr().String()
+}
+
+func TestPortServer_RFC2217(t *testing.T) {
+	backend := newLoopbackServer(t, true, false)
+	addr := startPortServer(t, "rfc2217://"+backend.addr(), &PortServer{RFC2217: true})
+
+	port, err := OpenPort("rfc2217://"+addr, &serial.Mode{BaudRate: 115200, DataBits: 8})
+	if err != nil {
+		t.Fatalf("OpenPort failed: %v", err)
+	}
+	defer port.Close()
+
+	frame := []byte{0x01, 0xFF, 0xFF, 0xFF}
+	if _, err := port.Write(frame); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if got := readFull(t, port, len(frame)); !bytes.Equal(got, frame) {
+		t.Errorf("Expected echo % X, got % X", frame, got)
+	}
+
+	// 远端客户端修改波特率，经共享的串口继续下发到后端
+	if err := port.SetMode(&serial.Mode{BaudRate: 921600, DataBits: 8}); err != nil {
+		t.Fatalf("SetMode failed: %v", err)
+	}
+	if baudRate, _, _ := backend.state(); baudRate != 921600 {
+		t.Errorf("Expected backend baud rate 921600, got %d", baudRate)
+	}
+}
+
+func TestPortServer_SingleWriter(t *testing.T) {
+	backend := newLoopbackServer(t, false, false)
+	addr := startPortServer(t, "tcp://"+backend.addr(), &PortServer{WriterIdle: 300 * time.Millisecond})
+
+	mode := &serial.Mode{BaudRate: 115200}
+	first, err := OpenPort("tcp://"+addr, mode)
+	if err != nil {
+		t.Fatalf("OpenPort failed: %v", err)
+	}
+	defer first.Close()
+
+	second, err := OpenPort("tcp://"+addr, mode)
+	if err != nil {
+		t.Fatalf("OpenPort failed: %v", err)
+	}
+	defer second.Close()
+
+	first.Write([]byte("page 1"))
+	// 两个客户端都能收到串口数据
+	readFull(t, first, 6)
+	readFull(t, second, 6)
+
+	// 第一个客户端仍持有写入权，第二个客户端的数据需要等待其空闲
+	second.Write([]byte("page 2"))
+	time.Sleep(100 * time.Millisecond)
+	if _, _, received := backend.state(); string(received) != "page 1" {
+		t.Errorf("Expected second writer to wait, backend received %q", received)
+	}
+
+	if got := readFull(t, second, 6); string(got) != "page 2" {
+		t.Errorf("Expected %q after writer idle, got %q", "page 2", got)
+	}
+	if _, _, received := backend.state(); string(received) != "page 1page 2" {
+		t.Errorf("Unexpected backend data %q", received)
+	}
+
+	// 写入者断开后立即释放写入权
+	second.Close()
+	time.Sleep(50 * time.Millisecond)
+	start := time.Now()
+	first.Write([]byte("page 3"))
+	readFull(t, first, 12)
+	if elapsed := time.Since(start); elapsed >= 300*time.Millisecond {
+		t.Errorf("Expected write right to be released on disconnect, waited %v", elapsed)
+	}
+}
+
+// TestPortServer_QueryWhileWaitingForWriter 测试等待写入权修改参数时不阻塞其他客户端查询参数
+func TestPortServer_QueryWhileWaitingForWriter(t *testing.T) {
+	backend := newLoopbackServer(t, true, false)
+	addr := startPortServer(t, "rfc2217://"+backend.addr(), &PortServer{RFC2217: true, WriterIdle: 500 * time.Millisecond})
+
+	mode := &serial.Mode{BaudRate: 115200, DataBits: 8}
+	ports := make([]Transport, 3)
+	for i := range ports {
+		port, err := OpenPort("rfc2217://"+addr, mode)
+		if err != nil {
+			t.Fatalf("OpenPort failed: %v", err)
+		}
+		defer port.Close()
+		ports[i] = port
+	}
+
+	// 第一个客户端持有写入权，第二个客户端修改波特率需要等待其空闲
+	ports[0].Write([]byte("page 1"))
+	readFull(t, ports[0], 6)
+	go ports[1].SetMode(&serial.Mode{BaudRate: 9600, DataBits: 8})
+	time.Sleep(50 * time.Millisecond)
+
+	// 参数不变的设置只是查询，不需要等待写入权
+	start := time.Now()
+	if err := ports[2].SetMode(mode); err != nil {
+		t.Fatalf("SetMode failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= 300*time.Millisecond {
+		t.Errorf("Expected query not to wait for the writer, waited %v", elapsed)
+	}
+}
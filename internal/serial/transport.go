@@ -0,0 +1,34 @@
+package serial
+
+import (
+	"io"
+	"time"
+
+	"go.bug.st/serial"
+)
+
+// Transport 数据传输通道，SerialPortManager 通过它完成实际的读写
+// 系统串口、TCP 桥接、PTY、内存模拟设备等都可以实现该接口
+type Transport interface {
+	io.ReadWriteCloser
+
+	// SetMode 设置波特率、校验位、数据位、停止位
+	SetMode(mode *serial.Mode) error
+	// SetReadTimeout 设置读超时，超时后 Read 返回 0 字节且不返回错误
+	SetReadTimeout(timeout time.Duration) error
+	// Drain 等待发送缓冲区中的数据全部发出
+	Drain() error
+}
+
+// Opener 按端口名和串口参数打开传输通道
+type Opener func(portName string, mode *serial.Mode) (Transport, error)
+
+// OpenSerialPort 打开系统串口作为传输通道，是 SerialPortManager 的默认实现
+func OpenSerialPort(portName string, mode *serial.Mode) (Transport, error) {
+	port, err := serial.Open(portName, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	return port, nil
+}
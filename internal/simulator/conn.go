@@ -0,0 +1,199 @@
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/internal/serial"
+	goserial "go.bug.st/serial"
+)
+
+var (
+	errClosed    = fmt.Errorf("simulator: connection closed: %w", io.ErrClosedPipe)
+	errUnplugged = errors.New("simulator: device unplugged")
+)
+
+// conn 主机与模拟设备之间的内存连接，实现 serial.Transport
+type conn struct {
+	device   *Device
+	mu       sync.Mutex
+	cond     *sync.Cond
+	buf      []byte // 设备发往主机、尚未被读取的数据
+	baudRate int
+	timeout  time.Duration
+	closed   bool
+}
+
+// Opener 返回连接到该模拟设备的传输通道打开函数，端口名会被忽略，设备拔出时打开失败
+func (d *Device) Opener() serial.Opener {
+	return func(portName string, mode *goserial.Mode) (serial.Transport, error) {
+		d.mu.Lock()
+		unplugged := d.unplugged
+		d.mu.Unlock()
+
+		if unplugged {
+			return nil, errUnplugged
+		}
+
+		return d.Connect(mode.BaudRate), nil
+	}
+}
+
+// Unplug 模拟拔出设备：断开所有连接，之后通过 Opener 打开连接会失败，直到调用 Plug
+func (d *Device) Unplug() {
+	d.mu.Lock()
+	d.unplugged = true
+	conns := d.conns
+	d.conns = make(map[*conn]struct{})
+	d.mu.Unlock()
+
+	for c := range conns {
+		c.mu.Lock()
+		c.closed = true
+		c.cond.Broadcast()
+		c.mu.Unlock()
+	}
+}
+
+// Plug 模拟重新插入设备
+func (d *Device) Plug() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.unplugged = false
+}
+
+// Connect 以指定波特率建立一条到模拟设备的连接
+func (d *Device) Connect(baudRate int) serial.Transport {
+	c := &conn{
+		device:   d,
+		baudRate: baudRate,
+		timeout:  goserial.NoTimeout,
+	}
+	c.cond = sync.NewCond(&c.mu)
+
+	d.mu.Lock()
+	d.conns[c] = struct{}{}
+	d.mu.Unlock()
+
+	return c
+}
+
+// push 追加设备发往主机的数据
+func (c *conn) push(data []byte) {
+	if len(data) == 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return
+	}
+
+	c.buf = append(c.buf, data...)
+	c.cond.Broadcast()
+}
+
+// Read 读取设备返回的数据，超时后返回 0 字节且不返回错误
+func (c *conn) Read(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var deadline time.Time
+	if c.timeout >= 0 {
+		deadline = time.Now().Add(c.timeout)
+	}
+
+	for len(c.buf) == 0 && !c.closed {
+		if c.timeout < 0 {
+			c.cond.Wait()
+			continue
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return 0, nil
+		}
+
+		// sync.Cond 不支持超时等待，借助定时器唤醒
+		timer := time.AfterFunc(remaining, func() {
+			c.mu.Lock()
+			c.cond.Broadcast()
+			c.mu.Unlock()
+		})
+		c.cond.Wait()
+		timer.Stop()
+	}
+
+	if c.closed {
+		return 0, errClosed
+	}
+
+	n := copy(p, c.buf)
+	c.buf = c.buf[n:]
+
+	return n, nil
+}
+
+// Write 将数据交给模拟设备处理
+func (c *conn) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	closed := c.closed
+	c.mu.Unlock()
+
+	if closed {
+		return 0, errClosed
+	}
+
+	c.device.receive(c, p)
+
+	return len(p), nil
+}
+
+func (c *conn) Close() error {
+	c.device.mu.Lock()
+	delete(c.device.conns, c)
+	c.device.mu.Unlock()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+	c.cond.Broadcast()
+
+	return nil
+}
+
+func (c *conn) SetMode(mode *goserial.Mode) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.baudRate = mode.BaudRate
+
+	return nil
+}
+
+func (c *conn) getBaudRate() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.baudRate
+}
+
+func (c *conn) SetReadTimeout(timeout time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.timeout = timeout
+
+	return nil
+}
+
+func (c *conn) Drain() error {
+	return nil
+}
@@ -0,0 +1,708 @@
+package simulator
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/consts"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/models"
+)
+
+// 指令结束符
+var endSymbol = []byte{0xFF, 0xFF, 0xFF}
+
+// Config 模拟设备配置
+type Config struct {
+	Info       models.DeviceInfo // connect 指令返回的设备信息
+	BaudRate   int               // 设备当前波特率，默认 115200
+	ReturnMode int               // bkcmd 初始值，为 0 时使用设备默认值 2（仅失败时返回）
+	Legacy     bool              // 旧固件，不支持 whmi-wris 升级指令
+
+	// 下载模式下超过该时间没有收到数据时放弃本次下载并重启，为 0 时使用 1s
+	UpgradeTimeout time.Duration
+}
+
+// Component 模拟设备上的控件
+type Component struct {
+	Page      int            // 所在页面
+	ID        int            // 控件ID
+	Name      string         // 控件名称，如 t0
+	Visible   bool           // 是否可见
+	Pressed   bool           // 是否处于按下状态
+	SendTouch bool           // 点击时是否发送 0x65 触摸事件
+	Attrs     map[string]any // 属性值，字符串属性为 string，数值属性为 int32
+	Channels  [][]byte       // 曲线控件各通道收到的数据点，其他控件为空
+}
+
+// Device 模拟的 TJC 串口屏，通过内存连接处理 TJC 指令集
+type Device struct {
+	mu            sync.Mutex
+	info          models.DeviceInfo
+	baudRate      int
+	returnMode    int
+	page          int
+	legacyUpgrade bool
+	unplugged     bool // 模拟设备已拔出
+	pages         []string
+	components    []*Component
+	sysVars       map[string]int32
+	conns         map[*conn]struct{}
+	pending       []byte   // 尚未组成完整指令的数据
+	history       []string // 已接收的指令
+
+	upgrade     upgradeState     // 升级状态
+	transparent transparentState // addt 透传状态
+}
+
+// New 创建模拟设备，默认只有一个名为 page0 的页面
+func New(cfg Config) *Device {
+	if cfg.Info.Model == "" {
+		cfg.Info = models.DeviceInfo{
+			Type:                  1,
+			Address:               "101-0",
+			Model:                 "TJC4024T032_011R",
+			FirmwareVersion:       52,
+			MainControlChipNumber: 61488,
+			Number:                "D264B8204F0E1828",
+			FlashSize:             16777216,
+		}
+	}
+	if cfg.BaudRate == 0 {
+		cfg.BaudRate = 115200
+	}
+	if cfg.ReturnMode == 0 {
+		cfg.ReturnMode = 2
+	}
+	if cfg.UpgradeTimeout == 0 {
+		cfg.UpgradeTimeout = defaultUpgradeTimeout
+	}
+
+	return &Device{
+		info:          cfg.Info,
+		baudRate:      cfg.BaudRate,
+		returnMode:    cfg.ReturnMode,
+		legacyUpgrade: cfg.Legacy,
+		pages:         []string{"page0"},
+		sysVars:       map[string]int32{"dim": 100, "dims": 100},
+		conns:         make(map[*conn]struct{}),
+		upgrade:       upgradeState{timeout: cfg.UpgradeTimeout, loseAt: -1, delayAt: -1, interruptAt: -1},
+	}
+}
+
+// AddPage 添加页面并返回页面ID
+func (d *Device) AddPage(name string) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.pages = append(d.pages, name)
+	return len(d.pages) - 1
+}
+
+// AddComponent 在指定页面添加控件，控件默认可见
+func (d *Device) AddComponent(page, id int, name string, attrs map[string]any) *Component {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if attrs == nil {
+		attrs = make(map[string]any)
+	}
+
+	comp := &Component{
+		Page:    page,
+		ID:      id,
+		Name:    name,
+		Visible: true,
+		Attrs:   attrs,
+	}
+	d.components = append(d.components, comp)
+
+	return comp
+}
+
+// Component 按页面和名称查找控件
+func (d *Device) Component(page int, name string) *Component {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.findComponent(page, name)
+}
+
+// Page 当前页面ID
+func (d *Device) Page() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.page
+}
+
+// BaudRate 设备当前波特率
+func (d *Device) BaudRate() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.baudRate
+}
+
+// ReturnMode 当前 bkcmd 值
+func (d *Device) ReturnMode() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.returnMode
+}
+
+// SysVar 读取系统变量，如 dim、sleep
+func (d *Device) SysVar(name string) int32 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.sysVars[name]
+}
+
+// History 返回设备收到的全部指令
+func (d *Device) History() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return append([]string(nil), d.history...)
+}
+
+// Emit 向所有连接主动发送一帧数据（自动追加结束符），用于模拟设备事件
+func (d *Device) Emit(code byte, payload ...byte) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	frame := append([]byte{code}, payload...)
+	d.broadcast(append(frame, endSymbol...))
+}
+
+// AutoSleep 模拟设备无操作超时（thsp/ussp）后自动进入睡眠，发送 0x86
+func (d *Device) AutoSleep() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.sysVars["sleep"] = 1
+	d.broadcast(append([]byte{consts.CodeAutoSleep}, endSymbol...))
+}
+
+// AutoWake 模拟设备自动唤醒，发送 0x87
+func (d *Device) AutoWake() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.wake()
+}
+
+// Sleeping 设备是否处于睡眠模式
+func (d *Device) Sleeping() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.sysVars["sleep"] == 1
+}
+
+// wake 退出睡眠并发送 0x87，调用方需持有 mu
+func (d *Device) wake() {
+	d.sysVars["sleep"] = 0
+	d.broadcast(append([]byte{consts.CodeAutoWake}, endSymbol...))
+}
+
+// Touch 模拟用户触摸控件，发送 0x65 触摸事件；睡眠时只在 thup=1 时唤醒设备
+func (d *Device) Touch(page, id int, pressed bool) {
+	d.mu.Lock()
+	sleeping := d.sysVars["sleep"] == 1
+	if sleeping && d.sysVars["thup"] == 1 {
+		d.wake()
+	}
+	d.mu.Unlock()
+
+	if sleeping {
+		return
+	}
+
+	state := byte(0)
+	if pressed {
+		state = 1
+	}
+	d.Emit(consts.CodeTouchEvent, byte(page), byte(id), state)
+}
+
+// receive 处理主机写入的数据，回复写入发送方连接
+func (d *Device) receive(c *conn, data []byte) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.checkUpgradeTimeout()
+
+	// 主机与设备波特率不一致时数据无法被正确接收
+	if c.getBaudRate() != d.baudRate {
+		return
+	}
+
+	if d.upgrade.active {
+		d.receiveUpgrade(c, data)
+		return
+	}
+
+	if d.transparent.active {
+		data = d.receiveTransparent(c, data)
+	}
+
+	d.pending = append(d.pending, data...)
+	for {
+		idx := bytes.Index(d.pending, endSymbol)
+		if idx < 0 {
+			return
+		}
+
+		instruction := string(d.pending[:idx])
+		d.pending = d.pending[idx+len(endSymbol):]
+		d.history = append(d.history, instruction)
+		d.execute(c, instruction)
+
+		// 升级指令之后的数据均为程序数据
+		if d.upgrade.active && len(d.pending) > 0 {
+			rest := d.pending
+			d.pending = nil
+			d.receiveUpgrade(c, rest)
+			return
+		}
+
+		// addt 指令之后的数据为透传数据
+		if d.transparent.active && len(d.pending) > 0 {
+			d.pending = d.receiveTransparent(c, d.pending)
+		}
+	}
+}
+
+// 睡眠时仍然响应的指令：系统变量赋值和 connect，其余指令被忽略
+var sleepInstructions = []string{"sleep", "dim", "dims", "baud", "bauds", "thsp", "ussp", "thup", "usup", "bkcmd", "wup", "connect"}
+
+// execute 执行单条指令
+func (d *Device) execute(c *conn, instruction string) {
+	instruction = strings.TrimSpace(instruction)
+
+	if d.sysVars["sleep"] == 1 {
+		name, _, _ := strings.Cut(instruction, "=")
+		if !slices.Contains(sleepInstructions, strings.TrimSpace(name)) {
+			// usup=1 时串口数据唤醒设备，否则指令无效
+			if d.sysVars["usup"] != 1 {
+				return
+			}
+			d.wake()
+		}
+	}
+
+	name, args, hasArgs := strings.Cut(instruction, " ")
+	if hasArgs {
+		switch name {
+		case "page":
+			d.execPage(c, args)
+			return
+		case "get":
+			d.execGet(c, args)
+			return
+		case "print":
+			d.execPrint(c, args)
+			return
+		case "printh":
+			d.execPrinth(c, args)
+			return
+		case "vis":
+			d.execVis(c, args)
+			return
+		case "click":
+			d.execClick(c, args)
+			return
+		case "add":
+			d.execAdd(c, args)
+			return
+		case "addt":
+			d.execAddt(c, args)
+			return
+		case "cle":
+			d.execCle(c, args)
+			return
+		case "whmi-wri":
+			d.execUpgrade(c, args, false)
+			return
+		case "whmi-wris":
+			if d.legacyUpgrade {
+				break
+			}
+			d.execUpgrade(c, args, true)
+			return
+		}
+	}
+
+	switch instruction {
+	case "connect":
+		c.push(d.connectReply())
+		return
+	case "sendme":
+		c.push(append([]byte{consts.CodePageID, byte(d.page)}, endSymbol...))
+		return
+	}
+
+	if target, value, ok := strings.Cut(instruction, "="); ok {
+		d.execAssign(c, strings.TrimSpace(target), strings.TrimSpace(value))
+		return
+	}
+
+	d.reply(c, consts.CodeInvalidInstruction)
+}
+
+func (d *Device) connectReply() []byte {
+	line := fmt.Sprintf("comok %d,%s,%s,%d,%d,%s,%d",
+		d.info.Type,
+		d.info.Address,
+		d.info.Model,
+		d.info.FirmwareVersion,
+		d.info.MainControlChipNumber,
+		d.info.Number,
+		d.info.FlashSize,
+	)
+
+	return append([]byte(line), endSymbol...)
+}
+
+func (d *Device) execPage(c *conn, args string) {
+	args = strings.TrimSpace(args)
+
+	page, err := strconv.Atoi(args)
+	if err != nil {
+		page = d.pageIndex(args)
+	}
+
+	if page < 0 || page >= len(d.pages) {
+		d.reply(c, consts.CodeInvalidPageID)
+		return
+	}
+
+	d.page = page
+	d.reply(c, consts.CodeSuccess)
+}
+
+func (d *Device) execGet(c *conn, args string) {
+	value, ok := d.lookup(strings.TrimSpace(args))
+	if !ok {
+		d.reply(c, consts.CodeInvalidVariableName)
+		return
+	}
+
+	switch v := value.(type) {
+	case string:
+		frame := append([]byte{consts.CodeStringData}, []byte(v)...)
+		c.push(append(frame, endSymbol...))
+	case int32:
+		frame := binary.LittleEndian.AppendUint32([]byte{consts.CodeNumberData}, uint32(v))
+		c.push(append(frame, endSymbol...))
+	}
+}
+
+func (d *Device) execPrint(c *conn, args string) {
+	args = strings.TrimSpace(args)
+
+	// 字符串常量原样输出
+	if s, ok := unquote(args); ok {
+		c.push([]byte(s))
+		return
+	}
+
+	value, ok := d.lookup(args)
+	if !ok {
+		d.reply(c, consts.CodeInvalidVariableName)
+		return
+	}
+
+	switch v := value.(type) {
+	case string:
+		c.push([]byte(v))
+	case int32:
+		c.push(binary.LittleEndian.AppendUint32(nil, uint32(v)))
+	}
+}
+
+func (d *Device) execPrinth(c *conn, args string) {
+	var out []byte
+	for _, field := range strings.Fields(args) {
+		b, err := strconv.ParseUint(field, 16, 8)
+		if err != nil {
+			d.reply(c, consts.CodeInvalidInstruction)
+			return
+		}
+		out = append(out, byte(b))
+	}
+
+	c.push(out)
+}
+
+func (d *Device) execVis(c *conn, args string) {
+	target, state, ok := parseTargetState(args)
+	if !ok {
+		d.reply(c, consts.CodeInvalidParamCount)
+		return
+	}
+
+	// vis 255 作用于当前页面全部控件
+	if target == "255" {
+		for _, comp := range d.components {
+			if comp.Page == d.page {
+				comp.Visible = state
+			}
+		}
+		d.reply(c, consts.CodeSuccess)
+		return
+	}
+
+	comp := d.findComponent(d.page, target)
+	if comp == nil {
+		d.reply(c, consts.CodeInvalidComponentID)
+		return
+	}
+
+	comp.Visible = state
+	d.reply(c, consts.CodeSuccess)
+}
+
+func (d *Device) execClick(c *conn, args string) {
+	target, pressed, ok := parseTargetState(args)
+	if !ok {
+		d.reply(c, consts.CodeInvalidParamCount)
+		return
+	}
+
+	comp := d.findComponent(d.page, target)
+	if comp == nil {
+		d.reply(c, consts.CodeInvalidComponentID)
+		return
+	}
+
+	comp.Pressed = pressed
+	d.reply(c, consts.CodeSuccess)
+
+	if comp.SendTouch {
+		state := byte(0)
+		if pressed {
+			state = 1
+		}
+		d.broadcast([]byte{consts.CodeTouchEvent, byte(comp.Page), byte(comp.ID), state, 0xFF, 0xFF, 0xFF})
+	}
+}
+
+func (d *Device) execAssign(c *conn, target, value string) {
+	// 系统变量
+	if !strings.Contains(target, ".") {
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			d.reply(c, consts.CodeAssignmentFailed)
+			return
+		}
+
+		switch target {
+		case "bkcmd":
+			if n < 0 || n > 3 {
+				d.reply(c, consts.CodeAssignmentFailed)
+				return
+			}
+			d.returnMode = int(n)
+		case "baud", "bauds":
+			// 先以原波特率回复，再切换到新波特率
+			if !slices.Contains(consts.SupportedBaudrate, int(n)) {
+				d.reply(c, consts.CodeInvalidBaudrate)
+				return
+			}
+			d.sysVars[target] = int32(n)
+			d.reply(c, consts.CodeSuccess)
+			d.baudRate = int(n)
+			return
+		}
+
+		d.sysVars[target] = int32(n)
+		d.reply(c, consts.CodeSuccess)
+		return
+	}
+
+	comp, attr := d.resolve(target)
+	if comp == nil {
+		d.reply(c, consts.CodeInvalidComponentID)
+		return
+	}
+
+	if s, ok := unquote(value); ok {
+		if _, isNumber := comp.Attrs[attr].(int32); isNumber {
+			d.reply(c, consts.CodeAssignmentFailed)
+			return
+		}
+		comp.Attrs[attr] = s
+		d.reply(c, consts.CodeSuccess)
+		return
+	}
+
+	n, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		d.reply(c, consts.CodeInvalidVariableOp)
+		return
+	}
+	if _, isString := comp.Attrs[attr].(string); isString {
+		d.reply(c, consts.CodeAssignmentFailed)
+		return
+	}
+
+	comp.Attrs[attr] = int32(n)
+	d.reply(c, consts.CodeSuccess)
+}
+
+// reply 根据 bkcmd 决定是否返回指令执行结果
+func (d *Device) reply(c *conn, code byte) {
+	success := code == consts.CodeSuccess
+
+	switch d.returnMode {
+	case 0:
+		return
+	case 1:
+		if !success {
+			return
+		}
+	case 2:
+		if success {
+			return
+		}
+	}
+
+	c.push([]byte{code, 0xFF, 0xFF, 0xFF})
+}
+
+// lookup 查找变量值，支持 sys0 等系统变量、t0.txt 以及 page0.t0.txt
+func (d *Device) lookup(target string) (any, bool) {
+	if !strings.Contains(target, ".") {
+		if target == "bkcmd" {
+			return int32(d.returnMode), true
+		}
+		v, ok := d.sysVars[target]
+		return v, ok
+	}
+
+	comp, attr := d.resolve(target)
+	if comp == nil {
+		return nil, false
+	}
+
+	// 控件ID为只读属性
+	if attr == "id" {
+		return int32(comp.ID), true
+	}
+
+	v, ok := comp.Attrs[attr]
+	return v, ok
+}
+
+// resolve 解析 [页面.]控件.属性 形式的目标
+func (d *Device) resolve(target string) (*Component, string) {
+	parts := strings.Split(target, ".")
+
+	page := d.page
+	switch len(parts) {
+	case 2:
+	case 3:
+		page = d.pageIndex(parts[0])
+		if page < 0 {
+			return nil, ""
+		}
+		parts = parts[1:]
+	default:
+		return nil, ""
+	}
+
+	return d.findComponent(page, parts[0]), parts[1]
+}
+
+func (d *Device) findComponent(page int, name string) *Component {
+	id, err := strconv.Atoi(name)
+	for _, comp := range d.components {
+		if comp.Page != page {
+			continue
+		}
+		if comp.Name == name || (err == nil && comp.ID == id) {
+			return comp
+		}
+	}
+
+	return nil
+}
+
+func (d *Device) pageIndex(name string) int {
+	for i, p := range d.pages {
+		if p == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (d *Device) broadcast(data []byte) {
+	for c := range d.conns {
+		c.push(data)
+	}
+}
+
+func parseTargetState(args string) (string, bool, bool) {
+	target, state, ok := strings.Cut(args, ",")
+	if !ok {
+		return "", false, false
+	}
+
+	switch strings.TrimSpace(state) {
+	case "0":
+		return strings.TrimSpace(target), false, true
+	case "1":
+		return strings.TrimSpace(target), true, true
+	}
+
+	return "", false, false
+}
+
+// unquote 解析字符串常量，从左到右一次处理 \\、\" 和 \r 转义
+func unquote(s string) (string, bool) {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return "", false
+	}
+
+	// 按字节处理，字符串可能是 GBK 等非 UTF-8 编码
+	var b strings.Builder
+	escaped := false
+	for i := 1; i < len(s)-1; i++ {
+		ch := s[i]
+		if !escaped {
+			if ch == '\\' {
+				escaped = true
+			} else {
+				b.WriteByte(ch)
+			}
+			continue
+		}
+
+		escaped = false
+		switch ch {
+		case 'r':
+			b.WriteByte('\r')
+		default:
+			// \\ 和 \" 以及未知转义都取转义后的字符
+			b.WriteByte(ch)
+		}
+	}
+
+	// 末尾的反斜杠转义了结束引号，字符串没有结束
+	if escaped {
+		return "", false
+	}
+
+	return b.String(), true
+}
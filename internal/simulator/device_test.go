@@ -0,0 +1,340 @@
+package simulator
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/internal/serial"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/components"
+	goserial "go.bug.st/serial"
+)
+
+// send 发送一条指令并读取设备在短时间内的全部返回
+func send(t *testing.T, port serial.Transport, instruction string) []byte {
+	t.Helper()
+
+	if _, err := port.Write(append([]byte(instruction), endSymbol...)); err != nil {
+		t.Fatalf("Write %q failed: %v", instruction, err)
+	}
+
+	return readAll(t, port)
+}
+
+func readAll(t *testing.T, port serial.Transport) []byte {
+	t.Helper()
+
+	var out []byte
+	buf := make([]byte, 256)
+	for {
+		n, err := port.Read(buf)
+		if err != nil {
+			t.Fatalf("Read failed: %v", err)
+		}
+		if n == 0 {
+			return out
+		}
+		out = append(out, buf[:n]...)
+	}
+}
+
+func newPort(d *Device) serial.Transport {
+	port := d.Connect(115200)
+	port.SetReadTimeout(10 * time.Millisecond)
+	return port
+}
+
+func TestDevice_Connect(t *testing.T) {
+	d := New(Config{})
+	port := newPort(d)
+	defer port.Close()
+
+	got := send(t, port, "connect")
+	want := append([]byte("comok 1,101-0,TJC4024T032_011R,52,61488,D264B8204F0E1828,16777216"), endSymbol...)
+	if !bytes.Equal(got, want) {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestDevice_ReturnMode(t *testing.T) {
+	testCases := []struct {
+		name        string
+		bkcmd       int
+		instruction string
+		expected    []byte
+	}{
+		{"DefaultSuccessSilent", 2, "page 0", nil},
+		{"DefaultFailure", 2, "page 9", []byte{0x03, 0xFF, 0xFF, 0xFF}},
+		{"SuccessOnly", 1, "page 0", []byte{0x01, 0xFF, 0xFF, 0xFF}},
+		{"SuccessOnlyFailureSilent", 1, "foo", nil},
+		{"AllSuccess", 3, "page 0", []byte{0x01, 0xFF, 0xFF, 0xFF}},
+		{"AllFailure", 3, "foo", []byte{0x00, 0xFF, 0xFF, 0xFF}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := New(Config{ReturnMode: tc.bkcmd})
+			port := newPort(d)
+			defer port.Close()
+
+			got := send(t, port, tc.instruction)
+			if !bytes.Equal(got, tc.expected) {
+				t.Errorf("Expected % X, got % X", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestDevice_PagesAndComponents(t *testing.T) {
+	d := New(Config{ReturnMode: 3})
+	main := d.AddPage("main")
+	d.AddComponent(main, 1, "t0", map[string]any{"txt": "hello"})
+	d.AddComponent(main, 2, "n0", map[string]any{"val": int32(-2)})
+	port := newPort(d)
+	defer port.Close()
+
+	send(t, port, "page main")
+	if got := send(t, port, "sendme"); !bytes.Equal(got, []byte{0x66, 0x01, 0xFF, 0xFF, 0xFF}) {
+		t.Errorf("Expected page 1, got % X", got)
+	}
+
+	if got := send(t, port, `t0.txt="a\"b"`); got[0] != 0x01 {
+		t.Errorf("Expected success, got % X", got)
+	}
+	if got := send(t, port, "get main.t0.txt"); !bytes.Equal(got, []byte{0x70, 'a', '"', 'b', 0xFF, 0xFF, 0xFF}) {
+		t.Errorf("Expected string data, got % X", got)
+	}
+	if got := send(t, port, "get n0.val"); !bytes.Equal(got, []byte{0x71, 0xFE, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}) {
+		t.Errorf("Expected number data, got % X", got)
+	}
+	if got := send(t, port, "print t0.txt"); string(got) != `a"b` {
+		t.Errorf("Expected raw print output, got % X", got)
+	}
+	if got := send(t, port, `n0.val="x"`); got[0] != 0x1C {
+		t.Errorf("Expected assignment failure, got % X", got)
+	}
+	if got := send(t, port, "vis t9,0"); got[0] != 0x02 {
+		t.Errorf("Expected invalid component, got % X", got)
+	}
+
+	send(t, port, "vis t0,0")
+	if d.Component(main, "t0").Visible {
+		t.Error("Expected t0 to be hidden")
+	}
+}
+
+func TestDevice_ClickSendsTouchEvent(t *testing.T) {
+	d := New(Config{})
+	d.AddComponent(0, 3, "b0", nil).SendTouch = true
+	port := newPort(d)
+	defer port.Close()
+
+	got := send(t, port, "click b0,1")
+	if !bytes.Equal(got, []byte{0x65, 0x00, 0x03, 0x01, 0xFF, 0xFF, 0xFF}) {
+		t.Errorf("Expected touch event, got % X", got)
+	}
+	if !d.Component(0, "b0").Pressed {
+		t.Error("Expected b0 to be pressed")
+	}
+}
+
+func TestDevice_BaudRateMismatch(t *testing.T) {
+	d := New(Config{BaudRate: 9600})
+	port := newPort(d)
+	defer port.Close()
+
+	if got := send(t, port, "connect"); len(got) != 0 {
+		t.Errorf("Expected no reply at wrong baud rate, got %q", got)
+	}
+
+	port.SetMode(&goserial.Mode{BaudRate: 9600})
+	if got := send(t, port, "connect"); len(got) == 0 {
+		t.Error("Expected reply at matching baud rate")
+	}
+}
+
+func TestDevice_Sleep(t *testing.T) {
+	d := New(Config{ReturnMode: 3})
+	port := newPort(d)
+	defer port.Close()
+
+	send(t, port, "sleep=1")
+
+	// 睡眠时忽略普通指令，系统变量赋值仍然有效
+	if got := send(t, port, "sendme"); len(got) != 0 {
+		t.Errorf("Expected no reply while sleeping, got % X", got)
+	}
+	if got := send(t, port, "usup=1"); !bytes.Equal(got, []byte{0x01, 0xFF, 0xFF, 0xFF}) {
+		t.Errorf("Expected success for usup, got % X", got)
+	}
+
+	// usup=1 时串口数据唤醒设备
+	want := []byte{0x87, 0xFF, 0xFF, 0xFF, 0x66, 0x00, 0xFF, 0xFF, 0xFF}
+	if got := send(t, port, "sendme"); !bytes.Equal(got, want) {
+		t.Errorf("Expected wake and page reply % X, got % X", want, got)
+	}
+	if d.Sleeping() {
+		t.Error("Expected device to be awake")
+	}
+}
+
+func TestDevice_Upgrade(t *testing.T) {
+	d := New(Config{})
+	port := newPort(d)
+	defer port.Close()
+
+	program := bytes.Repeat([]byte{0xAB}, 5000)
+	if got := send(t, port, "whmi-wri 5000,921600,0"); !bytes.Equal(got, []byte{0x05}) {
+		t.Fatalf("Expected 0x05 after whmi-wri, got % X", got)
+	}
+	if d.BaudRate() != 921600 {
+		t.Fatalf("Expected device to switch to 921600, got %d", d.BaudRate())
+	}
+
+	port.SetMode(&goserial.Mode{BaudRate: 921600})
+	port.Write(program[:4096])
+	if got := readAll(t, port); !bytes.Equal(got, []byte{0x05}) {
+		t.Fatalf("Expected 0x05 after first block, got % X", got)
+	}
+	port.Write(program[4096:])
+	if got := readAll(t, port); !bytes.Equal(got, []byte{0x05}) {
+		t.Fatalf("Expected 0x05 after last block, got % X", got)
+	}
+
+	if !bytes.Equal(d.UpgradeData(), program) {
+		t.Error("Expected upgrade data to match program")
+	}
+	if d.BaudRate() != 115200 {
+		t.Errorf("Expected device to restore 115200 after upgrade, got %d", d.BaudRate())
+	}
+}
+
+func TestDevice_UpgradeTimeout(t *testing.T) {
+	d := New(Config{UpgradeTimeout: 50 * time.Millisecond})
+	d.TruncateUpgradeBlock(4096, 1000)
+	port := newPort(d)
+	defer port.Close()
+
+	program := bytes.Repeat([]byte{0xAB}, 10000)
+	send(t, port, "whmi-wris 10000,921600,0")
+	port.SetMode(&goserial.Mode{BaudRate: 921600})
+	port.Write(program[:4096])
+	readAll(t, port)
+
+	// 第二块只收到前 1000 字节，不应答
+	port.Write(program[4096:8192])
+	if got := readAll(t, port); len(got) != 0 {
+		t.Fatalf("Expected no reply to a partial block, got % X", got)
+	}
+
+	// 超时后设备放弃下载，重新握手时第一块之后跳到已确认的 4096
+	time.Sleep(60 * time.Millisecond)
+	port.SetMode(&goserial.Mode{BaudRate: 115200})
+	send(t, port, "whmi-wris 10000,921600,0")
+	port.SetMode(&goserial.Mode{BaudRate: 921600})
+	port.Write(program[:4096])
+	want := []byte{0x08, 0x00, 0x10, 0x00, 0x00}
+	if got := readAll(t, port); !bytes.Equal(got, want) {
+		t.Fatalf("Expected % X after resync, got % X", want, got)
+	}
+}
+
+func TestDevice_DelayUpgradeAck(t *testing.T) {
+	d := New(Config{})
+	d.DelayUpgradeAck(0, 50*time.Millisecond)
+	port := newPort(d)
+	defer port.Close()
+
+	send(t, port, "whmi-wri 5000,921600,0")
+	port.SetMode(&goserial.Mode{BaudRate: 921600})
+	port.Write(bytes.Repeat([]byte{0xAB}, 4096))
+	if got := readAll(t, port); len(got) != 0 {
+		t.Fatalf("Expected the ack to be late, got % X", got)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if got := readAll(t, port); !bytes.Equal(got, []byte{0x05}) {
+		t.Errorf("Expected late 0x05, got % X", got)
+	}
+}
+
+func TestDevice_Transparent(t *testing.T) {
+	d := New(Config{ReturnMode: 3})
+	d.AddWaveform(0, 1, "s0", 2)
+	port := newPort(d)
+	defer port.Close()
+
+	ready := []byte{0xFE, 0xFF, 0xFF, 0xFF}
+	done := []byte{0xFD, 0xFF, 0xFF, 0xFF}
+
+	// 透传数据可以包含结束符，紧跟在指令之后发送
+	samples := []byte{0x10, 0xFF, 0xFF, 0xFF, 0x20}
+	port.Write(append([]byte("addt 1,1,5\xFF\xFF\xFF"), samples...))
+	if got := readAll(t, port); !bytes.Equal(got, append(ready, done...)) {
+		t.Fatalf("Expected ready and done, got % X", got)
+	}
+
+	if got := send(t, port, "addt 1,0,2"); !bytes.Equal(got, ready) {
+		t.Fatalf("Expected ready, got % X", got)
+	}
+	port.Write([]byte{0x01})
+	if got := readAll(t, port); len(got) != 0 {
+		t.Fatalf("Expected no reply before all data, got % X", got)
+	}
+	port.Write([]byte{0x02})
+	if got := readAll(t, port); !bytes.Equal(got, done) {
+		t.Fatalf("Expected done, got % X", got)
+	}
+
+	if !bytes.Equal(d.Waveform(0, "s0", 1), samples) || !bytes.Equal(d.Waveform(0, "s0", 0), []byte{0x01, 0x02}) {
+		t.Errorf("Unexpected waveform data % X, % X", d.Waveform(0, "s0", 0), d.Waveform(0, "s0", 1))
+	}
+
+	if got := send(t, port, "addt 1,2,1"); !bytes.Equal(got, []byte{0x12, 0xFF, 0xFF, 0xFF}) {
+		t.Errorf("Expected invalid curve for channel 2, got % X", got)
+	}
+}
+
+func TestUnquote_EscapeRoundTrip(t *testing.T) {
+	testCases := []string{
+		"hello",
+		`say "hi"`,
+		`C:\tjc`,
+		`\r is not a CR`,
+		`ends with \`,
+		`\"quoted\"`,
+		"line1\rline2",
+		"\xC4\xE3\xBA\xC3", // GBK 编码的“你好”
+	}
+
+	for _, want := range testCases {
+		got, ok := unquote(`"` + components.Escape(want) + `"`)
+		if !ok || got != want {
+			t.Errorf("Round trip %q: got %q, %v", want, got, ok)
+		}
+	}
+
+	if _, ok := unquote(`"abc\"`); ok {
+		t.Error("Expected escaped closing quote to be rejected")
+	}
+}
+
+func TestConn_ReadTimeoutAndClose(t *testing.T) {
+	d := New(Config{})
+	port := d.Connect(115200)
+	port.SetReadTimeout(20 * time.Millisecond)
+
+	start := time.Now()
+	n, err := port.Read(make([]byte, 1))
+	if n != 0 || err != nil {
+		t.Errorf("Expected timeout read to return 0, nil; got %d, %v", n, err)
+	}
+	if time.Since(start) < 20*time.Millisecond {
+		t.Error("Expected Read to wait for the timeout")
+	}
+
+	port.Close()
+	if _, err := port.Read(make([]byte, 1)); err == nil {
+		t.Error("Expected error when reading closed connection")
+	}
+}
@@ -0,0 +1,211 @@
+package simulator
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/consts"
+)
+
+// 升级时设备每接收满一块数据回复一次
+const upgradeBlockSize = 4096
+
+// 下载模式下默认的数据间隔超时，超时后设备放弃本次下载
+const defaultUpgradeTimeout = time.Second
+
+// 升级应答
+const (
+	upgradeAck  = 0x05 // 可以接收下一块数据
+	upgradeSkip = 0x08 // 跳转到指定偏移继续发送（whmi-wris），后跟 4 字节小端偏移
+)
+
+// upgradeState 升级过程状态
+type upgradeState struct {
+	active  bool
+	skip    bool // whmi-wris 协议
+	size    int
+	block   int // 当前块已接收字节数
+	data    bytes.Buffer
+	restore int           // 升级结束后恢复的波特率
+	last    time.Time     // 最后一次收到数据的时间
+	timeout time.Duration // 超过该时间没有收到数据时放弃下载
+
+	// 中断后保留的数据，用于 whmi-wris 断点续传
+	resumeData []byte
+
+	// 故障注入
+	loseAt      int           // 从该偏移开始丢失 loseLen 字节（仅一次），-1 表示不启用
+	loseLen     int           // 丢失的字节数
+	delayAt     int           // 延迟从该偏移开始的数据块的应答（仅一次），-1 表示不启用
+	delay       time.Duration // 应答延迟时间
+	interruptAt int           // 接收到该字节数后设备重启，-1 表示不启用
+}
+
+// UpgradeData 返回最近一次升级接收到的程序数据
+func (d *Device) UpgradeData() []byte {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return bytes.Clone(d.upgrade.data.Bytes())
+}
+
+// DropUpgradeBlock 丢弃从指定偏移开始的一个数据块且不应答，模拟传输丢失
+func (d *Device) DropUpgradeBlock(offset int) {
+	d.TruncateUpgradeBlock(offset, 0)
+}
+
+// TruncateUpgradeBlock 从指定偏移开始的数据块只收到前 received 字节，其余丢失且不应答，
+// 模拟传输中途丢失数据
+func (d *Device) TruncateUpgradeBlock(offset, received int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.upgrade.loseAt = offset + received
+	d.upgrade.loseLen = upgradeBlockSize - received
+}
+
+// DelayUpgradeAck 收满从指定偏移开始的数据块后延迟应答，模拟应答迟到
+func (d *Device) DelayUpgradeAck(offset int, delay time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.upgrade.delayAt = offset
+	d.upgrade.delay = delay
+}
+
+// InterruptUpgrade 接收到指定字节数后设备重启，模拟升级过程中断电或断线
+func (d *Device) InterruptUpgrade(afterBytes int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.upgrade.interruptAt = afterBytes
+}
+
+func (d *Device) execUpgrade(c *conn, args string, skip bool) {
+	// whmi-wri(s) 文件大小,波特率,预留参数
+	fields := strings.Split(args, ",")
+	if len(fields) != 3 {
+		d.reply(c, consts.CodeInvalidParamCount)
+		return
+	}
+
+	size, err1 := strconv.Atoi(strings.TrimSpace(fields[0]))
+	baud, err2 := strconv.Atoi(strings.TrimSpace(fields[1]))
+	if err1 != nil || err2 != nil || size <= 0 {
+		d.reply(c, consts.CodeInvalidInstruction)
+		return
+	}
+
+	u := &d.upgrade
+	u.active = true
+	u.skip = skip
+	u.size = size
+	u.block = 0
+	u.data.Reset()
+	u.restore = d.baudRate
+	u.last = time.Now()
+	if !skip || len(u.resumeData) > size {
+		u.resumeData = nil
+	}
+	d.baudRate = baud
+
+	c.push([]byte{upgradeAck})
+}
+
+// receiveUpgrade 接收升级数据，每满一块或全部接收完成时应答
+func (d *Device) receiveUpgrade(c *conn, data []byte) {
+	u := &d.upgrade
+	u.last = time.Now()
+
+	for len(data) > 0 && u.active {
+		// 故障注入：传输中丢失数据
+		if u.loseAt >= 0 && u.data.Len() == u.loseAt {
+			n := min(len(data), u.loseLen)
+			data = data[n:]
+			u.loseLen -= n
+			if u.loseLen == 0 {
+				u.loseAt = -1
+			}
+			continue
+		}
+
+		remain := u.size - u.data.Len()
+		blockRemain := upgradeBlockSize - u.block
+		n := min(len(data), remain, blockRemain)
+		if u.loseAt > u.data.Len() {
+			n = min(n, u.loseAt-u.data.Len())
+		}
+
+		// 故障注入：设备在接收过程中重启
+		if u.interruptAt >= 0 && u.data.Len()+n > u.interruptAt {
+			n = u.interruptAt - u.data.Len()
+			u.data.Write(data[:n])
+			d.rebootAfterUpgrade(true)
+			return
+		}
+
+		u.data.Write(data[:n])
+		u.block += n
+		data = data[n:]
+
+		if u.data.Len() >= u.size {
+			c.push([]byte{upgradeAck})
+			d.rebootAfterUpgrade(false)
+			return
+		}
+
+		if u.block < upgradeBlockSize {
+			continue
+		}
+		u.block = 0
+
+		// whmi-wris：收到第一块（含文件头）后告知已有数据的偏移，跳过重复传输
+		if u.skip && u.data.Len() == upgradeBlockSize && len(u.resumeData) > upgradeBlockSize &&
+			bytes.Equal(u.resumeData[:upgradeBlockSize], u.data.Bytes()) {
+			offset := len(u.resumeData) / upgradeBlockSize * upgradeBlockSize
+			u.data.Reset()
+			u.data.Write(u.resumeData[:offset])
+			u.resumeData = nil
+
+			c.push(binary.LittleEndian.AppendUint32([]byte{upgradeSkip}, uint32(offset)))
+			continue
+		}
+
+		// 故障注入：应答迟到
+		if u.delayAt >= 0 && u.data.Len()-upgradeBlockSize == u.delayAt {
+			u.delayAt = -1
+			time.AfterFunc(u.delay, func() { c.push([]byte{upgradeAck}) })
+			continue
+		}
+
+		c.push([]byte{upgradeAck})
+	}
+}
+
+// checkUpgradeTimeout 下载模式下长时间没有收到数据时放弃本次下载，
+// 与中断一样重启并保留已接收的数据，之后可以重新握手（whmi-wris 从已确认的偏移继续）
+func (d *Device) checkUpgradeTimeout() {
+	u := &d.upgrade
+	if u.active && time.Since(u.last) > u.timeout {
+		d.rebootAfterUpgrade(true)
+	}
+}
+
+// rebootAfterUpgrade 升级结束或中断后设备重启，恢复原波特率
+func (d *Device) rebootAfterUpgrade(interrupted bool) {
+	u := &d.upgrade
+	u.active = false
+	u.interruptAt = -1
+	if interrupted && u.skip {
+		u.resumeData = bytes.Clone(u.data.Bytes())
+	} else {
+		u.resumeData = nil
+	}
+
+	d.baudRate = u.restore
+	d.page = 0
+	d.pending = nil
+}
@@ -0,0 +1,159 @@
+package simulator
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/consts"
+)
+
+// 曲线控件最多 4 个通道
+const maxWaveformChannels = 4
+
+// addt 单次透传的最大字节数
+const maxTransparentSize = 1024
+
+// transparentState addt 透传状态，透传期间收到的数据均为曲线数据
+type transparentState struct {
+	active  bool
+	comp    *Component
+	channel int
+	remain  int
+}
+
+// AddWaveform 在指定页面添加曲线控件，channels 为通道数（1-4）
+func (d *Device) AddWaveform(page, id int, name string, channels int) *Component {
+	comp := d.AddComponent(page, id, name, nil)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	comp.Channels = make([][]byte, min(max(channels, 1), maxWaveformChannels))
+
+	return comp
+}
+
+// Waveform 返回曲线控件通道收到的全部数据点，控件或通道不存在时返回 nil
+func (d *Device) Waveform(page int, name string, channel int) []byte {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	comp := d.findComponent(page, name)
+	if comp == nil || channel < 0 || channel >= len(comp.Channels) {
+		return nil
+	}
+
+	return bytes.Clone(comp.Channels[channel])
+}
+
+// findWaveform 按控件ID和通道号查找当前页面的曲线控件，调用方需持有 mu
+func (d *Device) findWaveform(id, channel string) (*Component, int, bool) {
+	comp := d.findComponent(d.page, strings.TrimSpace(id))
+	if comp == nil || comp.Channels == nil {
+		return nil, 0, false
+	}
+
+	ch, err := strconv.Atoi(strings.TrimSpace(channel))
+	if err != nil || ch < 0 || ch >= len(comp.Channels) {
+		return nil, 0, false
+	}
+
+	return comp, ch, true
+}
+
+func (d *Device) execAdd(c *conn, args string) {
+	// add 控件ID,通道号,数据
+	fields := strings.Split(args, ",")
+	if len(fields) != 3 {
+		d.reply(c, consts.CodeInvalidParamCount)
+		return
+	}
+
+	comp, ch, ok := d.findWaveform(fields[0], fields[1])
+	if !ok {
+		d.reply(c, consts.CodeInvalidCurveID)
+		return
+	}
+
+	value, err := strconv.Atoi(strings.TrimSpace(fields[2]))
+	if err != nil {
+		d.reply(c, consts.CodeInvalidVariableOp)
+		return
+	}
+
+	// 超过 255 的数据只保留低 8 位
+	comp.Channels[ch] = append(comp.Channels[ch], byte(value))
+	d.reply(c, consts.CodeSuccess)
+}
+
+func (d *Device) execCle(c *conn, args string) {
+	// cle 控件ID,通道号，通道号为 255 时清除全部通道
+	id, channel, ok := strings.Cut(args, ",")
+	if !ok {
+		d.reply(c, consts.CodeInvalidParamCount)
+		return
+	}
+
+	if strings.TrimSpace(channel) == "255" {
+		comp := d.findComponent(d.page, strings.TrimSpace(id))
+		if comp == nil || comp.Channels == nil {
+			d.reply(c, consts.CodeInvalidCurveID)
+			return
+		}
+		for i := range comp.Channels {
+			comp.Channels[i] = nil
+		}
+		d.reply(c, consts.CodeSuccess)
+		return
+	}
+
+	comp, ch, ok := d.findWaveform(id, channel)
+	if !ok {
+		d.reply(c, consts.CodeInvalidCurveID)
+		return
+	}
+
+	comp.Channels[ch] = nil
+	d.reply(c, consts.CodeSuccess)
+}
+
+func (d *Device) execAddt(c *conn, args string) {
+	// addt 控件ID,通道号,数据数量
+	fields := strings.Split(args, ",")
+	if len(fields) != 3 {
+		d.reply(c, consts.CodeInvalidParamCount)
+		return
+	}
+
+	comp, ch, ok := d.findWaveform(fields[0], fields[1])
+	if !ok {
+		d.reply(c, consts.CodeInvalidCurveID)
+		return
+	}
+
+	qty, err := strconv.Atoi(strings.TrimSpace(fields[2]))
+	if err != nil || qty <= 0 || qty > maxTransparentSize {
+		d.reply(c, consts.CodeInvalidInstruction)
+		return
+	}
+
+	d.transparent = transparentState{active: true, comp: comp, channel: ch, remain: qty}
+	c.push(append([]byte{consts.CodeTransparentReady}, endSymbol...))
+}
+
+// receiveTransparent 接收透传数据，接收完成后回复 0xFD 并返回剩余数据
+func (d *Device) receiveTransparent(c *conn, data []byte) []byte {
+	t := &d.transparent
+
+	n := min(len(data), t.remain)
+	t.comp.Channels[t.channel] = append(t.comp.Channels[t.channel], data[:n]...)
+	t.remain -= n
+
+	if t.remain == 0 {
+		d.transparent = transparentState{}
+		c.push(append([]byte{consts.CodeTransparentDone}, endSymbol...))
+	}
+
+	return data[n:]
+}
@@ -1,11 +1,28 @@
 package client
 
 import (
+	"context"
+
 	"github.com/blue-cloud-net/tjc-serial-display/internal/client"
+	"github.com/blue-cloud-net/tjc-serial-display/internal/serial"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/components"
 	"github.com/blue-cloud-net/tjc-serial-display/pkg/models"
 )
 
+// 可替换的数据传输通道，如系统串口、TCP 桥接、PTY 或内存模拟设备
+type Transport = serial.Transport
+
+// 按端口名和串口参数打开传输通道
+type TransportOpener = serial.Opener
+
+// 升级中断错误，Offset 为设备已确认接收的字节数
+type UpgradeError = client.UpgradeError
+
+// 设备返回的错误码
+type TjcError = client.TjcError
+
 // 显示屏的客户端接口，定义了设备操作相关方法。
+// 注意：GetNumber、GetString 为新增方法，自行实现该接口的类型需要补充这两个方法。
 type DisplayClient interface {
 	// 获取设备信息
 	GetDeviceInfo() (*models.DeviceInfo, error)
@@ -20,6 +37,10 @@ type DisplayClient interface {
 	JumpPage(page int) error
 	// 打印目标的值或者输入内容
 	Prints(target string) (string, error)
+	// 获取目标的数值，如 n0.val
+	GetNumber(target string) (int32, error)
+	// 获取目标的字符串，如 t0.txt
+	GetString(target string) (string, error)
 	// 模拟弹起目标按钮
 	ClickUp(target string) error
 	// 模拟按下目标按钮
@@ -30,9 +51,69 @@ type DisplayClient interface {
 	Show(target string) error
 }
 
+// 扩展的客户端接口，在 DisplayClient 之上增加 context、控件句柄和升级选项等方法，
+// 本地客户端和 remote.Client 均实现该接口。
+// 这些方法单独放在新接口中，已有的 DisplayClient 实现不需要修改。
+type ExtendedClient interface {
+	DisplayClient
+
+	// 获取设备信息，context 取消或超时时立即返回
+	GetDeviceInfoContext(ctx context.Context) (*models.DeviceInfo, error)
+	// 执行原始 TJC 命令，context 取消或超时时立即返回
+	ExecuteCommandContext(ctx context.Context, cmd string) ([]byte, error)
+	// 执行指令并检查设备返回的错误码
+	Execute(instruction string) error
+	// 按选项升级面板程序，支持 whmi-wris 跳过已有数据、应答超时后重新握手
+	UpgradeWithOptions(programPath string, opts *models.UpgradeOptions) error
+	// 按选项升级面板程序，context 取消或超时时中止升级
+	UpgradeContext(ctx context.Context, programPath string, opts *models.UpgradeOptions) error
+
+	// 文本控件句柄
+	Text(name string) *components.Text
+	// 数字控件句柄
+	Number(name string) *components.Number
+	// 按钮控件句柄
+	Button(name string) *components.Button
+	// 滑块控件句柄
+	Slider(name string) *components.Slider
+	// 图片控件句柄
+	Picture(name string) *components.Picture
+	// 进度条控件句柄
+	ProgressBar(name string) *components.ProgressBar
+}
+
+// 订阅设备事件和串口原始数据的接口，由本地客户端实现。
+// remote.Client 的订阅需要报告连接错误，方法签名不同，见 remote.Subscription。
+type EventSubscriber interface {
+	// 订阅设备主动上报的事件（触摸、页面、睡眠唤醒等），返回取消订阅函数
+	Subscribe(callback models.EventCallback) func()
+	// 以通道形式订阅设备事件，返回取消订阅函数
+	Events(size int) (<-chan *models.Event, func())
+	// 订阅串口收到的每一帧原始数据，用于监视通信流量，返回取消订阅函数
+	SubscribeFrames(callback func(frame []byte)) func()
+}
+
+// 本地串口客户端实现的全部方法
+type LocalClient interface {
+	ExtendedClient
+	EventSubscriber
+}
+
+var _ LocalClient = (*client.TjcDisplayClient)(nil)
+
+// 创建串口客户端，返回值同时实现 LocalClient，需要扩展方法时可以类型断言
 func CreateClient(portName string, baudRate int) DisplayClient {
 	return &client.TjcDisplayClient{
 		PortName: portName,
 		BaudRate: baudRate,
 	}
 }
+
+// 使用自定义传输通道创建客户端，portName 原样传给 opener
+func CreateClientWithTransport(portName string, baudRate int, opener TransportOpener) DisplayClient {
+	return &client.TjcDisplayClient{
+		PortName: portName,
+		BaudRate: baudRate,
+		Opener:   opener,
+	}
+}
@@ -0,0 +1,144 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Executor 控件句柄依赖的客户端能力
+type Executor interface {
+	// Execute 执行指令并检查设备返回的错误码
+	Execute(instruction string) error
+	// GetNumber 获取数值属性
+	GetNumber(target string) (int32, error)
+	// GetString 获取字符串属性
+	GetString(target string) (string, error)
+}
+
+// Component 控件通用操作，name 可以是 t0 或 page0.t0 形式
+type Component struct {
+	exec Executor
+	name string
+}
+
+// Name 控件名称
+func (c *Component) Name() string {
+	return c.name
+}
+
+// Show 显示控件
+func (c *Component) Show() error {
+	return c.executeLocal("show", fmt.Sprintf("vis %s,1", c.name))
+}
+
+// Hide 隐藏控件
+func (c *Component) Hide() error {
+	return c.executeLocal("hide", fmt.Sprintf("vis %s,0", c.name))
+}
+
+// Refresh 刷新控件
+func (c *Component) Refresh() error {
+	return c.executeLocal("refresh", fmt.Sprintf("ref %s", c.name))
+}
+
+// SetNumber 设置任意数值属性，如 pco、bco
+func (c *Component) SetNumber(attr string, value int32) error {
+	return c.execute("set "+attr, fmt.Sprintf("%s.%s=%d", c.name, attr, value))
+}
+
+// GetNumber 读取任意数值属性
+func (c *Component) GetNumber(attr string) (int32, error) {
+	if err := c.validate("get " + attr); err != nil {
+		return 0, err
+	}
+
+	value, err := c.exec.GetNumber(c.name + "." + attr)
+	if err != nil {
+		return 0, wrapError(c.name, "get "+attr, err)
+	}
+
+	return value, nil
+}
+
+// SetString 设置任意字符串属性，自动转义引号、反斜杠和换行
+func (c *Component) SetString(attr string, value string) error {
+	return c.execute("set "+attr, fmt.Sprintf("%s.%s=\"%s\"", c.name, attr, Escape(value)))
+}
+
+// GetString 读取任意字符串属性
+func (c *Component) GetString(attr string) (string, error) {
+	if err := c.validate("get " + attr); err != nil {
+		return "", err
+	}
+
+	value, err := c.exec.GetString(c.name + "." + attr)
+	if err != nil {
+		return "", wrapError(c.name, "get "+attr, err)
+	}
+
+	return value, nil
+}
+
+func (c *Component) execute(op, instruction string) error {
+	if err := c.validate(op); err != nil {
+		return err
+	}
+
+	if err := c.exec.Execute(instruction); err != nil {
+		return wrapError(c.name, op, err)
+	}
+
+	return nil
+}
+
+// executeLocal 执行只作用于当前页面控件的指令（vis、ref、click）
+func (c *Component) executeLocal(op, instruction string) error {
+	if err := c.validateLocal(op); err != nil {
+		return err
+	}
+
+	return c.execute(op, instruction)
+}
+
+// validateLocal vis、ref、click 等指令只能操作当前页面的控件，
+// 带页面前缀的名称（page1.t0）无法指定其他页面，返回 ErrPageQualified 而不是误操作当前页面的同名控件
+func (c *Component) validateLocal(op string) error {
+	if err := c.validate(op); err != nil {
+		return err
+	}
+
+	if strings.Contains(c.name, ".") {
+		return &Error{Component: c.name, Op: op, Err: ErrPageQualified}
+	}
+
+	return nil
+}
+
+// validate 检查控件名称，避免拼接出非法指令
+func (c *Component) validate(op string) error {
+	if err := ValidateName(c.name); err != nil {
+		return &Error{Component: c.name, Op: op, Err: err}
+	}
+
+	return nil
+}
+
+// ValidateName 检查控件名称（如 t0、page1.t0）能否安全地拼接到指令中，不合法时返回 ErrInvalidName
+func ValidateName(name string) error {
+	if name == "" || strings.ContainsAny(name, " \",=\r\n\xFF") {
+		return ErrInvalidName
+	}
+
+	return nil
+}
+
+// Escape 转义 TJC 字符串常量中的特殊字符
+func Escape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\r\n", `\r`)
+	s = strings.ReplaceAll(s, "\n", `\r`)
+	s = strings.ReplaceAll(s, "\r", `\r`)
+
+	return s
+}
@@ -0,0 +1,89 @@
+package components
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/consts"
+)
+
+var (
+	ErrInvalidName      = errors.New("invalid component name")          // 控件名称不合法
+	ErrOutOfRange       = errors.New("value out of range")              // 属性值超出范围
+	ErrInvalidComponent = errors.New("invalid component")               // 控件ID无效
+	ErrInvalidPage      = errors.New("invalid page")                    // 页面ID无效
+	ErrInvalidPicture   = errors.New("invalid picture")                 // 图片ID无效
+	ErrInvalidFont      = errors.New("invalid font")                    // 字库ID无效
+	ErrInvalidCurve     = errors.New("invalid curve or channel")        // 曲线控件ID或通道号无效
+	ErrInvalidAttribute = errors.New("invalid attribute")               // 变量名称无效
+	ErrInvalidOperation = errors.New("invalid attribute operation")     // 变量运算无效
+	ErrAssignmentFailed = errors.New("assignment failed")               // 赋值操作失败
+	ErrPageQualified    = errors.New("page-qualified name not allowed") // 指令只作用于当前页面，不能带页面前缀
+)
+
+// 设备错误码到控件错误的映射
+var codeErrors = map[byte]error{
+	consts.CodeInvalidComponentID:  ErrInvalidComponent,
+	consts.CodeInvalidPageID:       ErrInvalidPage,
+	consts.CodeInvalidPictureID:    ErrInvalidPicture,
+	consts.CodeInvalidFontID:       ErrInvalidFont,
+	consts.CodeInvalidCurveID:      ErrInvalidCurve,
+	consts.CodeInvalidVariableName: ErrInvalidAttribute,
+	consts.CodeInvalidVariableOp:   ErrInvalidOperation,
+	consts.CodeAssignmentFailed:    ErrAssignmentFailed,
+}
+
+// Error 控件操作错误，可通过 errors.Is 与上面的错误变量比较
+type Error struct {
+	Component string // 控件名称
+	Op        string // 操作
+	Err       error  // 分类错误，如 ErrInvalidComponent
+	Cause     error  // 原始错误，如设备返回的错误码
+}
+
+func (e *Error) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("component %s: %s: %v (%v)", e.Component, e.Op, e.Err, e.Cause)
+	}
+
+	return fmt.Sprintf("component %s: %s: %v", e.Component, e.Op, e.Err)
+}
+
+func (e *Error) Unwrap() []error {
+	if e.Cause != nil {
+		return []error{e.Err, e.Cause}
+	}
+
+	return []error{e.Err}
+}
+
+// codedError 携带设备错误码的错误
+type codedError interface {
+	error
+	ErrorCode() byte
+}
+
+// wrapError 将设备错误码映射为控件错误，无法识别的错误原样返回
+func wrapError(component, op string, err error) error {
+	var coded codedError
+	if !errors.As(err, &coded) {
+		return err
+	}
+
+	mapped, ok := codeErrors[coded.ErrorCode()]
+	if !ok {
+		return err
+	}
+
+	return &Error{Component: component, Op: op, Err: mapped, Cause: err}
+}
+
+// rangeError 属性值超出范围
+func rangeError(component, op string, value, min, max int64) error {
+	return &Error{
+		Component: component,
+		Op:        op,
+		Err:       ErrOutOfRange,
+		Cause:     fmt.Errorf("%d not in [%d, %d]", value, min, max),
+	}
+}
@@ -0,0 +1,272 @@
+package components
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// 曲线控件最多 4 个通道（0-3）
+const maxWaveformChannel = 3
+
+// 数据点取值范围，对应曲线控件的高度像素
+const maxWaveformValue = 255
+
+// cle 指令清除全部通道时使用的通道号
+const allWaveformChannels = 255
+
+// 数据流默认的发送间隔和最多缓存的数据点数
+const (
+	defaultStreamInterval = 100 * time.Millisecond
+	maxStreamBuffer       = 4096
+)
+
+// WaveformExecutor 曲线控件依赖的客户端能力，在 Executor 之外需要支持 addt 透传
+type WaveformExecutor interface {
+	Executor
+	// WaveformAppend 通过 addt 透传向曲线控件的通道追加数据点
+	WaveformAppend(componentID, channel int, samples []byte) error
+}
+
+// Waveform 曲线控件，add、addt、cle 指令使用控件ID，首次使用时通过 id 属性读取
+type Waveform struct {
+	Component
+	wave WaveformExecutor
+
+	mu sync.Mutex
+	id int
+}
+
+// NewWaveform 创建曲线控件句柄
+func NewWaveform(exec WaveformExecutor, name string) *Waveform {
+	return &Waveform{Component: Component{exec: exec, name: name}, wave: exec, id: -1}
+}
+
+// Add 向通道追加一个数据点（0-255）
+func (w *Waveform) Add(channel, value int) error {
+	if value < 0 || value > maxWaveformValue {
+		return rangeError(w.name, "add", int64(value), 0, maxWaveformValue)
+	}
+
+	id, err := w.objectID("add", channel)
+	if err != nil {
+		return err
+	}
+
+	return w.execute("add", fmt.Sprintf("add %d,%d,%d", id, channel, value))
+}
+
+// Append 通过 addt 透传向通道追加一批数据点，数据点较多时比逐个 Add 快得多
+func (w *Waveform) Append(channel int, values []byte) error {
+	id, err := w.objectID("append", channel)
+	if err != nil {
+		return err
+	}
+
+	if err := w.wave.WaveformAppend(id, channel, values); err != nil {
+		return wrapError(w.name, "append", err)
+	}
+
+	return nil
+}
+
+// Clear 清除通道的全部数据点
+func (w *Waveform) Clear(channel int) error {
+	id, err := w.objectID("clear", channel)
+	if err != nil {
+		return err
+	}
+
+	return w.execute("clear", fmt.Sprintf("cle %d,%d", id, channel))
+}
+
+// ClearAll 清除全部通道的数据点
+func (w *Waveform) ClearAll() error {
+	id, err := w.objectID("clear", 0)
+	if err != nil {
+		return err
+	}
+
+	return w.execute("clear", fmt.Sprintf("cle %d,%d", id, allWaveformChannels))
+}
+
+// Stream 创建通道的数据流，数据点先缓存起来，每隔 interval 批量发送一次（默认 100ms）
+func (w *Waveform) Stream(channel int, interval time.Duration) *WaveformStream {
+	if interval <= 0 {
+		interval = defaultStreamInterval
+	}
+
+	s := &WaveformStream{
+		wave:    w,
+		channel: channel,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go s.run(interval)
+
+	return s
+}
+
+// objectID 校验通道号并返回控件ID，读取失败时下次重新读取
+// add、addt、cle 按控件ID操作当前页面，控件名不能带页面前缀
+func (w *Waveform) objectID(op string, channel int) (int, error) {
+	if channel < 0 || channel > maxWaveformChannel {
+		return 0, rangeError(w.name, op, int64(channel), 0, maxWaveformChannel)
+	}
+	if err := w.validateLocal(op); err != nil {
+		return 0, err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.id >= 0 {
+		return w.id, nil
+	}
+
+	id, err := w.GetNumber("id")
+	if err != nil {
+		return 0, err
+	}
+
+	w.id = int(id)
+	return w.id, nil
+}
+
+// WaveformStream 曲线控件的数据流，按固定间隔批量发送缓存的数据点，可以在多个协程中使用
+type WaveformStream struct {
+	wave    *Waveform
+	channel int
+
+	send    sync.Mutex // 保证批次按顺序发送
+	mu      sync.Mutex
+	buf     []byte
+	dropped int
+	err     error
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// Push 缓存数据点，等待下次批量发送；缓存超过上限时丢弃最早的数据点
+func (s *WaveformStream) Push(values ...byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.buf = append(s.buf, values...)
+	if over := len(s.buf) - maxStreamBuffer; over > 0 {
+		s.buf = s.buf[over:]
+		s.dropped += over
+	}
+}
+
+// PushScaled 按 scale 将数值映射为数据点后缓存
+func (s *WaveformStream) PushScaled(scale Scale, values ...float64) {
+	s.Push(scale.Points(values)...)
+}
+
+// Flush 立即发送缓存的数据点
+func (s *WaveformStream) Flush() error {
+	s.send.Lock()
+	defer s.send.Unlock()
+
+	s.mu.Lock()
+	values := s.buf
+	s.buf = nil
+	s.mu.Unlock()
+
+	var err error
+	switch len(values) {
+	case 0:
+		return nil
+	case 1:
+		// 单个数据点使用 add，省去透传的握手
+		err = s.wave.Add(s.channel, int(values[0]))
+	default:
+		err = s.wave.Append(s.channel, values)
+	}
+
+	if err != nil {
+		s.mu.Lock()
+		s.err = err
+		s.mu.Unlock()
+	}
+
+	return err
+}
+
+// Err 返回最近一次发送失败的错误
+func (s *WaveformStream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.err
+}
+
+// Dropped 因缓存超过上限而丢弃的数据点数
+func (s *WaveformStream) Dropped() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.dropped
+}
+
+// Close 停止数据流并发送剩余的数据点
+func (s *WaveformStream) Close() error {
+	s.once.Do(func() { close(s.stop) })
+	<-s.done
+
+	return s.Flush()
+}
+
+func (s *WaveformStream) run(interval time.Duration) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			// 发送失败的数据点被丢弃，错误通过 Err 获取
+			_ = s.Flush()
+		}
+	}
+}
+
+// Scale 将 [Min, Max] 范围的数值线性映射到曲线控件的 0-255 像素高度，超出范围的数值取边界
+type Scale struct {
+	Min float64
+	Max float64
+}
+
+// Point 将数值映射为一个数据点
+func (s Scale) Point(value float64) byte {
+	if s.Max == s.Min || math.IsNaN(value) {
+		return 0
+	}
+
+	ratio := (value - s.Min) / (s.Max - s.Min)
+	ratio = min(max(ratio, 0), 1)
+
+	return byte(math.Round(ratio * maxWaveformValue))
+}
+
+// Points 将一组数值映射为数据点
+func (s Scale) Points(values []float64) []byte {
+	points := make([]byte, len(values))
+	for i, value := range values {
+		points[i] = s.Point(value)
+	}
+
+	return points
+}
+
+// Value 将数据点还原为范围内的数值
+func (s Scale) Value(point byte) float64 {
+	return s.Min + float64(point)/maxWaveformValue*(s.Max-s.Min)
+}
@@ -0,0 +1,194 @@
+package components
+
+import "fmt"
+
+// 滑块属性的取值范围
+const (
+	sliderMin = 0
+	sliderMax = 65535
+)
+
+// 图片ID取值范围
+const maxPictureID = 65535
+
+// Text 文本控件（txt 属性）
+type Text struct {
+	Component
+}
+
+// NewText 创建文本控件句柄
+func NewText(exec Executor, name string) *Text {
+	return &Text{Component{exec: exec, name: name}}
+}
+
+// Set 设置文本
+func (t *Text) Set(text string) error {
+	return t.SetString("txt", text)
+}
+
+// Get 读取文本
+func (t *Text) Get() (string, error) {
+	return t.GetString("txt")
+}
+
+// Number 数字控件（val 属性）
+type Number struct {
+	Component
+}
+
+// NewNumber 创建数字控件句柄
+func NewNumber(exec Executor, name string) *Number {
+	return &Number{Component{exec: exec, name: name}}
+}
+
+// Set 设置数值
+func (n *Number) Set(value int32) error {
+	return n.SetNumber("val", value)
+}
+
+// Get 读取数值
+func (n *Number) Get() (int32, error) {
+	return n.GetNumber("val")
+}
+
+// Button 按钮控件
+type Button struct {
+	Component
+}
+
+// NewButton 创建按钮控件句柄
+func NewButton(exec Executor, name string) *Button {
+	return &Button{Component{exec: exec, name: name}}
+}
+
+// Press 模拟按下
+func (b *Button) Press() error {
+	return b.executeLocal("press", fmt.Sprintf("click %s,1", b.name))
+}
+
+// Release 模拟弹起
+func (b *Button) Release() error {
+	return b.executeLocal("release", fmt.Sprintf("click %s,0", b.name))
+}
+
+// Click 模拟一次完整的按下和弹起
+func (b *Button) Click() error {
+	if err := b.Press(); err != nil {
+		return err
+	}
+
+	return b.Release()
+}
+
+// SetText 设置按钮文字
+func (b *Button) SetText(text string) error {
+	return b.SetString("txt", text)
+}
+
+// GetText 读取按钮文字
+func (b *Button) GetText() (string, error) {
+	return b.GetString("txt")
+}
+
+// Slider 滑块控件（val、minval、maxval 属性）
+type Slider struct {
+	Component
+	min      int32
+	max      int32
+	hasRange bool
+}
+
+// NewSlider 创建滑块控件句柄
+func NewSlider(exec Executor, name string) *Slider {
+	return &Slider{Component: Component{exec: exec, name: name}}
+}
+
+// SetRange 设置滑块最小值和最大值，之后 Set 会按该范围校验
+func (s *Slider) SetRange(min, max int32) error {
+	if min < sliderMin || min > sliderMax {
+		return rangeError(s.name, "set range", int64(min), sliderMin, sliderMax)
+	}
+	if max < min || max > sliderMax {
+		return rangeError(s.name, "set range", int64(max), int64(min), sliderMax)
+	}
+
+	if err := s.SetNumber("minval", min); err != nil {
+		return err
+	}
+	if err := s.SetNumber("maxval", max); err != nil {
+		return err
+	}
+
+	s.min, s.max, s.hasRange = min, max, true
+
+	return nil
+}
+
+// Set 设置滑块当前值
+func (s *Slider) Set(value int32) error {
+	min, max := int32(sliderMin), int32(sliderMax)
+	if s.hasRange {
+		min, max = s.min, s.max
+	}
+
+	if value < min || value > max {
+		return rangeError(s.name, "set val", int64(value), int64(min), int64(max))
+	}
+
+	return s.SetNumber("val", value)
+}
+
+// Get 读取滑块当前值
+func (s *Slider) Get() (int32, error) {
+	return s.GetNumber("val")
+}
+
+// Picture 图片控件（pic 属性）
+type Picture struct {
+	Component
+}
+
+// NewPicture 创建图片控件句柄
+func NewPicture(exec Executor, name string) *Picture {
+	return &Picture{Component{exec: exec, name: name}}
+}
+
+// SetPic 切换显示的图片资源ID
+func (p *Picture) SetPic(id int) error {
+	if id < 0 || id > maxPictureID {
+		return rangeError(p.name, "set pic", int64(id), 0, maxPictureID)
+	}
+
+	return p.SetNumber("pic", int32(id))
+}
+
+// GetPic 读取当前图片资源ID
+func (p *Picture) GetPic() (int, error) {
+	id, err := p.GetNumber("pic")
+	return int(id), err
+}
+
+// ProgressBar 进度条控件（val 属性，0-100）
+type ProgressBar struct {
+	Component
+}
+
+// NewProgressBar 创建进度条控件句柄
+func NewProgressBar(exec Executor, name string) *ProgressBar {
+	return &ProgressBar{Component{exec: exec, name: name}}
+}
+
+// SetVal 设置进度百分比
+func (p *ProgressBar) SetVal(value int) error {
+	if value < 0 || value > 100 {
+		return rangeError(p.name, "set val", int64(value), 0, 100)
+	}
+
+	return p.SetNumber("val", int32(value))
+}
+
+// GetVal 读取进度百分比
+func (p *ProgressBar) GetVal() (int, error) {
+	value, err := p.GetNumber("val")
+	return int(value), err
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// ConnectionEvent 串口连接状态变化
+type ConnectionEvent struct {
+	Connected bool      // true 为已重新连接，false 为已断开
+	Err       error     // 断开的原因（断开时）
+	Time      time.Time // 发生时间
+}
+
+// ConnectionCallback 连接状态回调函数类型
+type ConnectionCallback func(event *ConnectionEvent)
+
+func (e *ConnectionEvent) String() string {
+	if e.Connected {
+		return "connected"
+	}
+	if e.Err != nil {
+		return "disconnected: " + e.Err.Error()
+	}
+
+	return "disconnected"
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// EventType 设备主动上报的事件类型
+type EventType int
+
+const (
+	EventTouch           EventType = iota // 控件触摸事件（0x65）
+	EventPage                             // 页面ID上报（0x66，非指令返回时）
+	EventTouchCoordinate                  // 触摸坐标上报（0x67）
+	EventSleepTouch                       // 睡眠模式下的触摸（0x68）
+	EventAutoSleep                        // 设备自动进入睡眠（0x86）
+	EventAutoWake                         // 设备自动唤醒（0x87）
+	EventStartup                          // 系统启动成功（0x88）
+	EventSDUpgrade                        // 开始SD卡升级（0x89）
+)
+
+// Event 设备事件
+type Event struct {
+	Type      EventType // 事件类型
+	Code      byte      // 原始事件码
+	Page      int       // 页面ID（触摸事件、页面上报）
+	Component int       // 控件ID（触摸事件）
+	Pressed   bool      // true 为按下，false 为弹起（触摸事件、坐标上报）
+	X         int       // 横坐标（坐标上报）
+	Y         int       // 纵坐标（坐标上报）
+	Raw       []byte    // 原始数据（不含结束符）
+	Time      time.Time // 接收时间
+}
+
+// EventCallback 事件回调函数类型
+type EventCallback func(event *Event)
+
+var eventTypeNames = map[EventType]string{
+	EventTouch:           "touch",
+	EventPage:            "page",
+	EventTouchCoordinate: "touch_xy",
+	EventSleepTouch:      "sleep_touch",
+	EventAutoSleep:       "sleep",
+	EventAutoWake:        "wake",
+	EventStartup:         "startup",
+	EventSDUpgrade:       "sd_upgrade",
+}
+
+func (t EventType) String() string {
+	if name, ok := eventTypeNames[t]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("unknown(%d)", int(t))
+}
+
+// String 事件的可读描述
+func (e *Event) String() string {
+	state := "released"
+	if e.Pressed {
+		state = "pressed"
+	}
+
+	switch e.Type {
+	case EventTouch:
+		return fmt.Sprintf("touch page=%d id=%d %s", e.Page, e.Component, state)
+	case EventPage:
+		return fmt.Sprintf("page %d", e.Page)
+	case EventTouchCoordinate, EventSleepTouch:
+		return fmt.Sprintf("%s x=%d y=%d %s", e.Type, e.X, e.Y, state)
+	}
+
+	return e.Type.String()
+}
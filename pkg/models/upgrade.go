@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// UpgradeOptions 升级选项
+type UpgradeOptions struct {
+	BaudRate   int                     // 下载波特率，为 0 时使用 921600
+	Legacy     bool                    // 仅使用 whmi-wri 协议，不尝试 whmi-wris
+	Retries    int                     // 数据块应答超时后重新握手的次数，为 0 时使用 3，小于 0 时不重试
+	ResyncWait time.Duration           // 应答超时后等待设备放弃本次下载再重新握手的时间，为 0 时使用 3s
+	Force      bool                    // 跳过升级前的程序文件型号检查
+	Progress   UpgradeProgressCallback // 进度回调
+}
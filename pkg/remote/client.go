@@ -0,0 +1,457 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/client"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/components"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/models"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/remote/displaypb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// 上传程序文件时每条消息携带的字节数
+const uploadChunkSize = 64 * 1024
+
+// 服务端总是在升级前检查程序文件型号，gRPC 接口不提供跳过检查的选项
+var errForceUnsupported = errors.New("remote upgrade does not support skipping the tft model check")
+
+// Client 通过 gRPC 访问远程显示屏，实现 client.ExtendedClient，可与本地客户端互换使用
+// 设备返回的错误码还原为 *client.TjcError，升级中断还原为 *client.UpgradeError
+// 订阅方法返回 *Subscription，可以获知连接断开等订阅结束的原因
+type Client struct {
+	rpc  displaypb.DisplayServiceClient
+	conn *grpc.ClientConn // Dial 创建的连接，Close 时关闭
+}
+
+var _ client.ExtendedClient = (*Client)(nil)
+
+// NewClient 使用已有的 gRPC 连接创建客户端
+func NewClient(conn grpc.ClientConnInterface) *Client {
+	return &Client{rpc: displaypb.NewDisplayServiceClient(conn)}
+}
+
+// Dial 连接 tjc serve --grpc 提供的服务，未指定选项时使用不加密的连接
+func Dial(target string, opts ...grpc.DialOption) (*Client, error) {
+	if len(opts) == 0 {
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{rpc: displaypb.NewDisplayServiceClient(conn), conn: conn}, nil
+}
+
+// Close 关闭 Dial 创建的连接
+func (c *Client) Close() error {
+	if c.conn != nil {
+		return c.conn.Close()
+	}
+
+	return nil
+}
+
+func (c *Client) GetDeviceInfo() (*models.DeviceInfo, error) {
+	return c.GetDeviceInfoContext(context.Background())
+}
+
+func (c *Client) GetDeviceInfoContext(ctx context.Context) (*models.DeviceInfo, error) {
+	info, err := c.rpc.GetDeviceInfo(ctx, &displaypb.GetDeviceInfoRequest{})
+	if err != nil {
+		return nil, fromStatus(ctx, err)
+	}
+
+	return &models.DeviceInfo{
+		Type:                  int(info.GetType()),
+		Address:               info.GetAddress(),
+		Model:                 info.GetModel(),
+		FirmwareVersion:       int(info.GetFirmwareVersion()),
+		MainControlChipNumber: int(info.GetMainControlChipNumber()),
+		Number:                info.GetNumber(),
+		FlashSize:             int(info.GetFlashSize()),
+	}, nil
+}
+
+func (c *Client) ExecuteCommand(cmd string) ([]byte, error) {
+	return c.ExecuteCommandContext(context.Background(), cmd)
+}
+
+func (c *Client) ExecuteCommandContext(ctx context.Context, cmd string) ([]byte, error) {
+	resp, err := c.rpc.ExecuteCommand(ctx, &displaypb.ExecuteCommandRequest{Command: cmd})
+	if err != nil {
+		return nil, fromStatus(ctx, err)
+	}
+
+	return resp.GetResult(), nil
+}
+
+func (c *Client) Execute(instruction string) error {
+	ctx := context.Background()
+	_, err := c.rpc.Execute(ctx, &displaypb.ExecuteRequest{Instruction: instruction})
+	return fromStatus(ctx, err)
+}
+
+func (c *Client) Upgrade(programPath string, baudRate int, progressCallback models.UpgradeProgressCallback) error {
+	return c.UpgradeWithOptions(programPath, &models.UpgradeOptions{
+		BaudRate: baudRate,
+		Progress: progressCallback,
+	})
+}
+
+func (c *Client) UpgradeWithOptions(programPath string, opts *models.UpgradeOptions) error {
+	return c.UpgradeContext(context.Background(), programPath, opts)
+}
+
+// UpgradeContext 将本地程序文件上传到服务端并升级，进度由服务端推送
+// 程序文件的型号检查由服务端在升级前完成，远程升级不支持 opts.Force
+func (c *Client) UpgradeContext(ctx context.Context, programPath string, opts *models.UpgradeOptions) error {
+	if opts == nil {
+		opts = &models.UpgradeOptions{}
+	}
+	if opts.Force {
+		return errForceUnsupported
+	}
+
+	f, err := os.Open(programPath)
+	if err != nil {
+		return fmt.Errorf("failed to open program file: %w", err)
+	}
+	defer f.Close()
+
+	fileInfo, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to get file info: %w", err)
+	}
+
+	stream, err := c.rpc.Upgrade(ctx)
+	if err != nil {
+		return fromStatus(ctx, err)
+	}
+
+	err = c.uploadProgram(stream, f, &displaypb.UpgradeOptions{
+		Size:     fileInfo.Size(),
+		BaudRate: int32(opts.BaudRate),
+		Legacy:   opts.Legacy,
+		Retries:  int32(opts.Retries),
+	})
+	// 服务端提前结束时 Send 返回 io.EOF，实际错误由 Recv 返回
+	if err != nil && err != io.EOF {
+		return fromStatus(ctx, err)
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fromStatus(ctx, err)
+		}
+
+		if opts.Progress != nil {
+			opts.Progress(&models.UpgradeProgress{
+				Current:    resp.GetCurrent(),
+				Total:      resp.GetTotal(),
+				Percentage: resp.GetPercentage(),
+				Speed:      resp.GetSpeed(),
+				Elapsed:    time.Duration(resp.GetElapsedMs()) * time.Millisecond,
+				Remaining:  time.Duration(resp.GetRemainingMs()) * time.Millisecond,
+			})
+		}
+	}
+}
+
+// uploadProgram 先发送升级选项，再分块发送程序文件
+func (c *Client) uploadProgram(stream displaypb.DisplayService_UpgradeClient, r io.Reader, options *displaypb.UpgradeOptions) error {
+	err := stream.Send(&displaypb.UpgradeRequest{Payload: &displaypb.UpgradeRequest_Options{Options: options}})
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, uploadChunkSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			sendErr := stream.Send(&displaypb.UpgradeRequest{Payload: &displaypb.UpgradeRequest_Chunk{Chunk: buf[:n]}})
+			if sendErr != nil {
+				return sendErr
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read program file: %w", err)
+		}
+	}
+
+	return stream.CloseSend()
+}
+
+func (c *Client) GetPage() (int, error) {
+	ctx := context.Background()
+	resp, err := c.rpc.GetPage(ctx, &displaypb.GetPageRequest{})
+	if err != nil {
+		return 0, fromStatus(ctx, err)
+	}
+
+	return int(resp.GetPage()), nil
+}
+
+func (c *Client) JumpPage(page int) error {
+	ctx := context.Background()
+	_, err := c.rpc.JumpPage(ctx, &displaypb.JumpPageRequest{Page: int32(page)})
+	return fromStatus(ctx, err)
+}
+
+func (c *Client) Prints(target string) (string, error) {
+	ctx := context.Background()
+	resp, err := c.rpc.Prints(ctx, &displaypb.PrintsRequest{Target: target})
+	if err != nil {
+		return "", fromStatus(ctx, err)
+	}
+
+	return resp.GetValue(), nil
+}
+
+func (c *Client) GetNumber(target string) (int32, error) {
+	ctx := context.Background()
+	resp, err := c.rpc.GetNumber(ctx, &displaypb.GetNumberRequest{Target: target})
+	if err != nil {
+		return 0, fromStatus(ctx, err)
+	}
+
+	return resp.GetValue(), nil
+}
+
+func (c *Client) GetString(target string) (string, error) {
+	ctx := context.Background()
+	resp, err := c.rpc.GetString(ctx, &displaypb.GetStringRequest{Target: target})
+	if err != nil {
+		return "", fromStatus(ctx, err)
+	}
+
+	return resp.GetValue(), nil
+}
+
+func (c *Client) ClickUp(target string) error {
+	return c.click(target, false)
+}
+
+func (c *Client) ClickDown(target string) error {
+	return c.click(target, true)
+}
+
+func (c *Client) click(target string, pressed bool) error {
+	ctx := context.Background()
+	_, err := c.rpc.Click(ctx, &displaypb.ClickRequest{Target: target, Pressed: pressed})
+	return fromStatus(ctx, err)
+}
+
+func (c *Client) Hide(target string) error {
+	ctx := context.Background()
+	_, err := c.rpc.Hide(ctx, &displaypb.HideRequest{Target: target})
+	return fromStatus(ctx, err)
+}
+
+func (c *Client) Show(target string) error {
+	ctx := context.Background()
+	_, err := c.rpc.Show(ctx, &displaypb.ShowRequest{Target: target})
+	return fromStatus(ctx, err)
+}
+
+func (c *Client) Text(name string) *components.Text {
+	return components.NewText(c, name)
+}
+
+func (c *Client) Number(name string) *components.Number {
+	return components.NewNumber(c, name)
+}
+
+func (c *Client) Button(name string) *components.Button {
+	return components.NewButton(c, name)
+}
+
+func (c *Client) Slider(name string) *components.Slider {
+	return components.NewSlider(c, name)
+}
+
+func (c *Client) Picture(name string) *components.Picture {
+	return components.NewPicture(c, name)
+}
+
+func (c *Client) ProgressBar(name string) *components.ProgressBar {
+	return components.NewProgressBar(c, name)
+}
+
+// Subscription 远程订阅，服务端结束推送或连接断开时订阅结束，不会自动重新订阅
+type Subscription struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error // 订阅结束的原因，done 关闭后有效
+}
+
+// Cancel 取消订阅
+func (s *Subscription) Cancel() {
+	s.cancel()
+}
+
+// Done 订阅结束时关闭，包括调用 Cancel 和连接断开
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err 返回订阅结束的原因：连接断开时为 gRPC 错误，服务端结束推送时为 io.EOF；
+// 订阅仍在进行或由 Cancel 取消时返回 nil
+func (s *Subscription) Err() error {
+	select {
+	case <-s.done:
+		return s.err
+	default:
+		return nil
+	}
+}
+
+// Subscribe 订阅远程设备事件，返回时服务端已开始推送；无法建立订阅时返回错误
+func (c *Client) Subscribe(callback models.EventCallback) (*Subscription, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	stream, err := c.rpc.SubscribeEvents(ctx, &displaypb.SubscribeEventsRequest{})
+	if err != nil {
+		cancel()
+		return nil, fromStatus(ctx, err)
+	}
+
+	return startSubscription(ctx, cancel, stream, func(event *displaypb.Event) {
+		callback(eventFromProto(event))
+	})
+}
+
+// Events 以通道形式订阅远程设备事件，通道已满时丢弃新事件，订阅结束后关闭通道
+func (c *Client) Events(size int) (<-chan *models.Event, *Subscription, error) {
+	ch := make(chan *models.Event, size)
+
+	sub, err := c.Subscribe(func(event *models.Event) {
+		select {
+		case ch <- event:
+		default:
+		}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 回调在接收协程中调用，done 关闭后不会再写入通道
+	go func() {
+		<-sub.Done()
+		close(ch)
+	}()
+
+	return ch, sub, nil
+}
+
+// SubscribeFrames 订阅远程串口收到的每一帧原始数据；无法建立订阅时返回错误
+func (c *Client) SubscribeFrames(callback func(frame []byte)) (*Subscription, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	stream, err := c.rpc.SubscribeFrames(ctx, &displaypb.SubscribeFramesRequest{})
+	if err != nil {
+		cancel()
+		return nil, fromStatus(ctx, err)
+	}
+
+	return startSubscription(ctx, cancel, stream, func(frame *displaypb.Frame) {
+		callback(frame.GetData())
+	})
+}
+
+// startSubscription 等待服务端确认订阅后在后台接收推送，直到订阅结束
+func startSubscription[T any](ctx context.Context, cancel context.CancelFunc, stream grpc.ServerStreamingClient[T], handle func(*T)) (*Subscription, error) {
+	// 等待响应头，确认服务端订阅已生效
+	if _, err := stream.Header(); err != nil {
+		cancel()
+		return nil, fromStatus(ctx, err)
+	}
+
+	sub := &Subscription{cancel: cancel, done: make(chan struct{})}
+	go func() {
+		defer close(sub.done)
+		defer cancel()
+
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				if ctx.Err() == nil {
+					sub.err = fromStatus(ctx, err)
+				}
+				return
+			}
+			handle(msg)
+		}
+	}()
+
+	return sub, nil
+}
+
+func eventFromProto(event *displaypb.Event) *models.Event {
+	return &models.Event{
+		Type:      models.EventType(event.GetType() - 1),
+		Code:      byte(event.GetCode()),
+		Page:      int(event.GetPage()),
+		Component: int(event.GetComponent()),
+		Pressed:   event.GetPressed(),
+		X:         int(event.GetX()),
+		Y:         int(event.GetY()),
+		Raw:       event.GetRaw(),
+		Time:      time.Unix(0, event.GetTimeUnixNano()),
+	}
+}
+
+// fromStatus 将 gRPC 错误还原为本地客户端的错误类型，context 已结束时返回 context 的错误
+func fromStatus(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	var tjcErr *client.TjcError
+	var failure *displaypb.UpgradeFailure
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *displaypb.DeviceError:
+			tjcErr = &client.TjcError{Code: byte(d.GetCode()), Message: d.GetMessage()}
+		case *displaypb.UpgradeFailure:
+			failure = d
+		}
+	}
+
+	if failure != nil {
+		cause := errors.New(st.Message())
+		if tjcErr != nil {
+			cause = tjcErr
+		}
+		return &client.UpgradeError{Offset: failure.GetOffset(), Err: cause}
+	}
+	if tjcErr != nil {
+		return tjcErr
+	}
+
+	return err
+}
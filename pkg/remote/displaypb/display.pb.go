@@ -0,0 +1,1845 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: tjc/v1/display.proto
+
+// 显示屏远程控制服务，与 pkg/client.DisplayClient 接口一一对应
+
+package displaypb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type EventType int32
+
+const (
+	EventType_EVENT_TYPE_UNSPECIFIED      EventType = 0
+	EventType_EVENT_TYPE_TOUCH            EventType = 1 // 控件触摸事件（0x65）
+	EventType_EVENT_TYPE_PAGE             EventType = 2 // 页面ID上报（0x66）
+	EventType_EVENT_TYPE_TOUCH_COORDINATE EventType = 3 // 触摸坐标上报（0x67）
+	EventType_EVENT_TYPE_SLEEP_TOUCH      EventType = 4 // 睡眠模式下的触摸（0x68）
+	EventType_EVENT_TYPE_AUTO_SLEEP       EventType = 5 // 设备自动进入睡眠（0x86）
+	EventType_EVENT_TYPE_AUTO_WAKE        EventType = 6 // 设备自动唤醒（0x87）
+	EventType_EVENT_TYPE_STARTUP          EventType = 7 // 系统启动成功（0x88）
+	EventType_EVENT_TYPE_SD_UPGRADE       EventType = 8 // 开始SD卡升级（0x89）
+)
+
+// Enum value maps for EventType.
+var (
+	EventType_name = map[int32]string{
+		0: "EVENT_TYPE_UNSPECIFIED",
+		1: "EVENT_TYPE_TOUCH",
+		2: "EVENT_TYPE_PAGE",
+		3: "EVENT_TYPE_TOUCH_COORDINATE",
+		4: "EVENT_TYPE_SLEEP_TOUCH",
+		5: "EVENT_TYPE_AUTO_SLEEP",
+		6: "EVENT_TYPE_AUTO_WAKE",
+		7: "EVENT_TYPE_STARTUP",
+		8: "EVENT_TYPE_SD_UPGRADE",
+	}
+	EventType_value = map[string]int32{
+		"EVENT_TYPE_UNSPECIFIED":      0,
+		"EVENT_TYPE_TOUCH":            1,
+		"EVENT_TYPE_PAGE":             2,
+		"EVENT_TYPE_TOUCH_COORDINATE": 3,
+		"EVENT_TYPE_SLEEP_TOUCH":      4,
+		"EVENT_TYPE_AUTO_SLEEP":       5,
+		"EVENT_TYPE_AUTO_WAKE":        6,
+		"EVENT_TYPE_STARTUP":          7,
+		"EVENT_TYPE_SD_UPGRADE":       8,
+	}
+)
+
+func (x EventType) Enum() *EventType {
+	p := new(EventType)
+	*p = x
+	return p
+}
+
+func (x EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_tjc_v1_display_proto_enumTypes[0].Descriptor()
+}
+
+func (EventType) Type() protoreflect.EnumType {
+	return &file_tjc_v1_display_proto_enumTypes[0]
+}
+
+func (x EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventType.Descriptor instead.
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{0}
+}
+
+type GetDeviceInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeviceInfoRequest) Reset() {
+	*x = GetDeviceInfoRequest{}
+	mi := &file_tjc_v1_display_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeviceInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeviceInfoRequest) ProtoMessage() {}
+
+func (x *GetDeviceInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeviceInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetDeviceInfoRequest) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{0}
+}
+
+type DeviceInfo struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Type                  int32                  `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`                                                                    // 屏幕类型（0:非触摸屏；1:电阻屏；2:电容屏）
+	Address               string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`                                                               // 设备地址
+	Model                 string                 `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`                                                                   // 设备型号
+	FirmwareVersion       int32                  `protobuf:"varint,4,opt,name=firmware_version,json=firmwareVersion,proto3" json:"firmware_version,omitempty"`                       // 固件版本号
+	MainControlChipNumber int32                  `protobuf:"varint,5,opt,name=main_control_chip_number,json=mainControlChipNumber,proto3" json:"main_control_chip_number,omitempty"` // 主控芯片编号
+	Number                string                 `protobuf:"bytes,6,opt,name=number,proto3" json:"number,omitempty"`                                                                 // 设备唯一编号
+	FlashSize             int64                  `protobuf:"varint,7,opt,name=flash_size,json=flashSize,proto3" json:"flash_size,omitempty"`                                         // Flash 存储大小（字节）
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *DeviceInfo) Reset() {
+	*x = DeviceInfo{}
+	mi := &file_tjc_v1_display_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceInfo) ProtoMessage() {}
+
+func (x *DeviceInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceInfo.ProtoReflect.Descriptor instead.
+func (*DeviceInfo) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DeviceInfo) GetType() int32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *DeviceInfo) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *DeviceInfo) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *DeviceInfo) GetFirmwareVersion() int32 {
+	if x != nil {
+		return x.FirmwareVersion
+	}
+	return 0
+}
+
+func (x *DeviceInfo) GetMainControlChipNumber() int32 {
+	if x != nil {
+		return x.MainControlChipNumber
+	}
+	return 0
+}
+
+func (x *DeviceInfo) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *DeviceInfo) GetFlashSize() int64 {
+	if x != nil {
+		return x.FlashSize
+	}
+	return 0
+}
+
+type ExecuteCommandRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Command       string                 `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecuteCommandRequest) Reset() {
+	*x = ExecuteCommandRequest{}
+	mi := &file_tjc_v1_display_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecuteCommandRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteCommandRequest) ProtoMessage() {}
+
+func (x *ExecuteCommandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteCommandRequest.ProtoReflect.Descriptor instead.
+func (*ExecuteCommandRequest) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ExecuteCommandRequest) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+type ExecuteCommandResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        []byte                 `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"` // 设备原始应答，没有应答时为空
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecuteCommandResponse) Reset() {
+	*x = ExecuteCommandResponse{}
+	mi := &file_tjc_v1_display_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecuteCommandResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteCommandResponse) ProtoMessage() {}
+
+func (x *ExecuteCommandResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteCommandResponse.ProtoReflect.Descriptor instead.
+func (*ExecuteCommandResponse) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ExecuteCommandResponse) GetResult() []byte {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type ExecuteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instruction   string                 `protobuf:"bytes,1,opt,name=instruction,proto3" json:"instruction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecuteRequest) Reset() {
+	*x = ExecuteRequest{}
+	mi := &file_tjc_v1_display_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecuteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteRequest) ProtoMessage() {}
+
+func (x *ExecuteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteRequest.ProtoReflect.Descriptor instead.
+func (*ExecuteRequest) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ExecuteRequest) GetInstruction() string {
+	if x != nil {
+		return x.Instruction
+	}
+	return ""
+}
+
+type ExecuteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecuteResponse) Reset() {
+	*x = ExecuteResponse{}
+	mi := &file_tjc_v1_display_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecuteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteResponse) ProtoMessage() {}
+
+func (x *ExecuteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteResponse.ProtoReflect.Descriptor instead.
+func (*ExecuteResponse) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{5}
+}
+
+type GetPageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPageRequest) Reset() {
+	*x = GetPageRequest{}
+	mi := &file_tjc_v1_display_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPageRequest) ProtoMessage() {}
+
+func (x *GetPageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPageRequest.ProtoReflect.Descriptor instead.
+func (*GetPageRequest) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{6}
+}
+
+type GetPageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPageResponse) Reset() {
+	*x = GetPageResponse{}
+	mi := &file_tjc_v1_display_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPageResponse) ProtoMessage() {}
+
+func (x *GetPageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPageResponse.ProtoReflect.Descriptor instead.
+func (*GetPageResponse) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetPageResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+type JumpPageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JumpPageRequest) Reset() {
+	*x = JumpPageRequest{}
+	mi := &file_tjc_v1_display_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JumpPageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JumpPageRequest) ProtoMessage() {}
+
+func (x *JumpPageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JumpPageRequest.ProtoReflect.Descriptor instead.
+func (*JumpPageRequest) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *JumpPageRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+type JumpPageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JumpPageResponse) Reset() {
+	*x = JumpPageResponse{}
+	mi := &file_tjc_v1_display_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JumpPageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JumpPageResponse) ProtoMessage() {}
+
+func (x *JumpPageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JumpPageResponse.ProtoReflect.Descriptor instead.
+func (*JumpPageResponse) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{9}
+}
+
+type PrintsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Target        string                 `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PrintsRequest) Reset() {
+	*x = PrintsRequest{}
+	mi := &file_tjc_v1_display_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PrintsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrintsRequest) ProtoMessage() {}
+
+func (x *PrintsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrintsRequest.ProtoReflect.Descriptor instead.
+func (*PrintsRequest) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PrintsRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+type PrintsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PrintsResponse) Reset() {
+	*x = PrintsResponse{}
+	mi := &file_tjc_v1_display_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PrintsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrintsResponse) ProtoMessage() {}
+
+func (x *PrintsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrintsResponse.ProtoReflect.Descriptor instead.
+func (*PrintsResponse) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PrintsResponse) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type GetNumberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Target        string                 `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNumberRequest) Reset() {
+	*x = GetNumberRequest{}
+	mi := &file_tjc_v1_display_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNumberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNumberRequest) ProtoMessage() {}
+
+func (x *GetNumberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNumberRequest.ProtoReflect.Descriptor instead.
+func (*GetNumberRequest) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetNumberRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+type GetNumberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         int32                  `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNumberResponse) Reset() {
+	*x = GetNumberResponse{}
+	mi := &file_tjc_v1_display_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNumberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNumberResponse) ProtoMessage() {}
+
+func (x *GetNumberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNumberResponse.ProtoReflect.Descriptor instead.
+func (*GetNumberResponse) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetNumberResponse) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type GetStringRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Target        string                 `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStringRequest) Reset() {
+	*x = GetStringRequest{}
+	mi := &file_tjc_v1_display_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStringRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStringRequest) ProtoMessage() {}
+
+func (x *GetStringRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStringRequest.ProtoReflect.Descriptor instead.
+func (*GetStringRequest) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetStringRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+type GetStringResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStringResponse) Reset() {
+	*x = GetStringResponse{}
+	mi := &file_tjc_v1_display_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStringResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStringResponse) ProtoMessage() {}
+
+func (x *GetStringResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStringResponse.ProtoReflect.Descriptor instead.
+func (*GetStringResponse) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetStringResponse) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type ClickRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Target        string                 `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	Pressed       bool                   `protobuf:"varint,2,opt,name=pressed,proto3" json:"pressed,omitempty"` // true 按下，false 弹起
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClickRequest) Reset() {
+	*x = ClickRequest{}
+	mi := &file_tjc_v1_display_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClickRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClickRequest) ProtoMessage() {}
+
+func (x *ClickRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClickRequest.ProtoReflect.Descriptor instead.
+func (*ClickRequest) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ClickRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *ClickRequest) GetPressed() bool {
+	if x != nil {
+		return x.Pressed
+	}
+	return false
+}
+
+type ClickResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClickResponse) Reset() {
+	*x = ClickResponse{}
+	mi := &file_tjc_v1_display_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClickResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClickResponse) ProtoMessage() {}
+
+func (x *ClickResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClickResponse.ProtoReflect.Descriptor instead.
+func (*ClickResponse) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{17}
+}
+
+type ShowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Target        string                 `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShowRequest) Reset() {
+	*x = ShowRequest{}
+	mi := &file_tjc_v1_display_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShowRequest) ProtoMessage() {}
+
+func (x *ShowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShowRequest.ProtoReflect.Descriptor instead.
+func (*ShowRequest) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ShowRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+type ShowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShowResponse) Reset() {
+	*x = ShowResponse{}
+	mi := &file_tjc_v1_display_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShowResponse) ProtoMessage() {}
+
+func (x *ShowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShowResponse.ProtoReflect.Descriptor instead.
+func (*ShowResponse) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{19}
+}
+
+type HideRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Target        string                 `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HideRequest) Reset() {
+	*x = HideRequest{}
+	mi := &file_tjc_v1_display_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HideRequest) ProtoMessage() {}
+
+func (x *HideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HideRequest.ProtoReflect.Descriptor instead.
+func (*HideRequest) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *HideRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+type HideResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HideResponse) Reset() {
+	*x = HideResponse{}
+	mi := &file_tjc_v1_display_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HideResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HideResponse) ProtoMessage() {}
+
+func (x *HideResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HideResponse.ProtoReflect.Descriptor instead.
+func (*HideResponse) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{21}
+}
+
+type UpgradeOptions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Size          int64                  `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`                         // 程序文件大小
+	BaudRate      int32                  `protobuf:"varint,2,opt,name=baud_rate,json=baudRate,proto3" json:"baud_rate,omitempty"` // 下载波特率，为 0 时使用 921600
+	Legacy        bool                   `protobuf:"varint,3,opt,name=legacy,proto3" json:"legacy,omitempty"`                     // 仅使用 whmi-wri 协议
+	Retries       int32                  `protobuf:"varint,4,opt,name=retries,proto3" json:"retries,omitempty"`                   // 应答超时后重新握手的次数，为 0 时使用 3，小于 0 时不重试
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpgradeOptions) Reset() {
+	*x = UpgradeOptions{}
+	mi := &file_tjc_v1_display_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpgradeOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpgradeOptions) ProtoMessage() {}
+
+func (x *UpgradeOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpgradeOptions.ProtoReflect.Descriptor instead.
+func (*UpgradeOptions) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UpgradeOptions) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *UpgradeOptions) GetBaudRate() int32 {
+	if x != nil {
+		return x.BaudRate
+	}
+	return 0
+}
+
+func (x *UpgradeOptions) GetLegacy() bool {
+	if x != nil {
+		return x.Legacy
+	}
+	return false
+}
+
+func (x *UpgradeOptions) GetRetries() int32 {
+	if x != nil {
+		return x.Retries
+	}
+	return 0
+}
+
+type UpgradeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*UpgradeRequest_Options
+	//	*UpgradeRequest_Chunk
+	Payload       isUpgradeRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpgradeRequest) Reset() {
+	*x = UpgradeRequest{}
+	mi := &file_tjc_v1_display_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpgradeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpgradeRequest) ProtoMessage() {}
+
+func (x *UpgradeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpgradeRequest.ProtoReflect.Descriptor instead.
+func (*UpgradeRequest) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UpgradeRequest) GetPayload() isUpgradeRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *UpgradeRequest) GetOptions() *UpgradeOptions {
+	if x != nil {
+		if x, ok := x.Payload.(*UpgradeRequest_Options); ok {
+			return x.Options
+		}
+	}
+	return nil
+}
+
+func (x *UpgradeRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*UpgradeRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isUpgradeRequest_Payload interface {
+	isUpgradeRequest_Payload()
+}
+
+type UpgradeRequest_Options struct {
+	Options *UpgradeOptions `protobuf:"bytes,1,opt,name=options,proto3,oneof"` // 第一条消息
+}
+
+type UpgradeRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"` // 之后按顺序发送的程序文件内容
+}
+
+func (*UpgradeRequest_Options) isUpgradeRequest_Payload() {}
+
+func (*UpgradeRequest_Chunk) isUpgradeRequest_Payload() {}
+
+type UpgradeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Current       int64                  `protobuf:"varint,1,opt,name=current,proto3" json:"current,omitempty"`                            // 已发送字节数
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`                                // 总字节数
+	Percentage    float64                `protobuf:"fixed64,3,opt,name=percentage,proto3" json:"percentage,omitempty"`                     // 百分比
+	Speed         int64                  `protobuf:"varint,4,opt,name=speed,proto3" json:"speed,omitempty"`                                // 传输速度（字节/秒）
+	ElapsedMs     int64                  `protobuf:"varint,5,opt,name=elapsed_ms,json=elapsedMs,proto3" json:"elapsed_ms,omitempty"`       // 已用时间（毫秒）
+	RemainingMs   int64                  `protobuf:"varint,6,opt,name=remaining_ms,json=remainingMs,proto3" json:"remaining_ms,omitempty"` // 预计剩余时间（毫秒）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpgradeResponse) Reset() {
+	*x = UpgradeResponse{}
+	mi := &file_tjc_v1_display_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpgradeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpgradeResponse) ProtoMessage() {}
+
+func (x *UpgradeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpgradeResponse.ProtoReflect.Descriptor instead.
+func (*UpgradeResponse) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *UpgradeResponse) GetCurrent() int64 {
+	if x != nil {
+		return x.Current
+	}
+	return 0
+}
+
+func (x *UpgradeResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *UpgradeResponse) GetPercentage() float64 {
+	if x != nil {
+		return x.Percentage
+	}
+	return 0
+}
+
+func (x *UpgradeResponse) GetSpeed() int64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *UpgradeResponse) GetElapsedMs() int64 {
+	if x != nil {
+		return x.ElapsedMs
+	}
+	return 0
+}
+
+func (x *UpgradeResponse) GetRemainingMs() int64 {
+	if x != nil {
+		return x.RemainingMs
+	}
+	return 0
+}
+
+type SubscribeEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeEventsRequest) Reset() {
+	*x = SubscribeEventsRequest{}
+	mi := &file_tjc_v1_display_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeEventsRequest) ProtoMessage() {}
+
+func (x *SubscribeEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeEventsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeEventsRequest) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{25}
+}
+
+type Event struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          EventType              `protobuf:"varint,1,opt,name=type,proto3,enum=tjc.v1.EventType" json:"type,omitempty"`
+	Code          uint32                 `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`                                       // 原始事件码
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`                                       // 页面ID
+	Component     int32                  `protobuf:"varint,4,opt,name=component,proto3" json:"component,omitempty"`                             // 控件ID
+	Pressed       bool                   `protobuf:"varint,5,opt,name=pressed,proto3" json:"pressed,omitempty"`                                 // true 为按下，false 为弹起
+	X             int32                  `protobuf:"varint,6,opt,name=x,proto3" json:"x,omitempty"`                                             // 横坐标
+	Y             int32                  `protobuf:"varint,7,opt,name=y,proto3" json:"y,omitempty"`                                             // 纵坐标
+	Raw           []byte                 `protobuf:"bytes,8,opt,name=raw,proto3" json:"raw,omitempty"`                                          // 原始数据（不含结束符）
+	TimeUnixNano  int64                  `protobuf:"varint,9,opt,name=time_unix_nano,json=timeUnixNano,proto3" json:"time_unix_nano,omitempty"` // 接收时间
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_tjc_v1_display_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Event) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *Event) GetCode() uint32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *Event) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *Event) GetComponent() int32 {
+	if x != nil {
+		return x.Component
+	}
+	return 0
+}
+
+func (x *Event) GetPressed() bool {
+	if x != nil {
+		return x.Pressed
+	}
+	return false
+}
+
+func (x *Event) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Event) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *Event) GetRaw() []byte {
+	if x != nil {
+		return x.Raw
+	}
+	return nil
+}
+
+func (x *Event) GetTimeUnixNano() int64 {
+	if x != nil {
+		return x.TimeUnixNano
+	}
+	return 0
+}
+
+type SubscribeFramesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeFramesRequest) Reset() {
+	*x = SubscribeFramesRequest{}
+	mi := &file_tjc_v1_display_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeFramesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeFramesRequest) ProtoMessage() {}
+
+func (x *SubscribeFramesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeFramesRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeFramesRequest) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{27}
+}
+
+type Frame struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Frame) Reset() {
+	*x = Frame{}
+	mi := &file_tjc_v1_display_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Frame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Frame) ProtoMessage() {}
+
+func (x *Frame) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Frame.ProtoReflect.Descriptor instead.
+func (*Frame) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *Frame) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// 设备返回的错误码，作为 gRPC 错误的详情返回
+type DeviceError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          uint32                 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeviceError) Reset() {
+	*x = DeviceError{}
+	mi := &file_tjc_v1_display_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceError) ProtoMessage() {}
+
+func (x *DeviceError) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceError.ProtoReflect.Descriptor instead.
+func (*DeviceError) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DeviceError) GetCode() uint32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *DeviceError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// 升级中断，作为 gRPC 错误的详情返回
+type UpgradeFailure struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Offset        int64                  `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"` // 设备已确认接收的字节数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpgradeFailure) Reset() {
+	*x = UpgradeFailure{}
+	mi := &file_tjc_v1_display_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpgradeFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpgradeFailure) ProtoMessage() {}
+
+func (x *UpgradeFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_tjc_v1_display_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpgradeFailure.ProtoReflect.Descriptor instead.
+func (*UpgradeFailure) Descriptor() ([]byte, []int) {
+	return file_tjc_v1_display_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *UpgradeFailure) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+var File_tjc_v1_display_proto protoreflect.FileDescriptor
+
+const file_tjc_v1_display_proto_rawDesc = "" +
+	"\n" +
+	"\x14tjc/v1/display.proto\x12\x06tjc.v1\"\x16\n" +
+	"\x14GetDeviceInfoRequest\"\xeb\x01\n" +
+	"\n" +
+	"DeviceInfo\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\x05R\x04type\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x14\n" +
+	"\x05model\x18\x03 \x01(\tR\x05model\x12)\n" +
+	"\x10firmware_version\x18\x04 \x01(\x05R\x0ffirmwareVersion\x127\n" +
+	"\x18main_control_chip_number\x18\x05 \x01(\x05R\x15mainControlChipNumber\x12\x16\n" +
+	"\x06number\x18\x06 \x01(\tR\x06number\x12\x1d\n" +
+	"\n" +
+	"flash_size\x18\a \x01(\x03R\tflashSize\"1\n" +
+	"\x15ExecuteCommandRequest\x12\x18\n" +
+	"\acommand\x18\x01 \x01(\tR\acommand\"0\n" +
+	"\x16ExecuteCommandResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\fR\x06result\"2\n" +
+	"\x0eExecuteRequest\x12 \n" +
+	"\vinstruction\x18\x01 \x01(\tR\vinstruction\"\x11\n" +
+	"\x0fExecuteResponse\"\x10\n" +
+	"\x0eGetPageRequest\"%\n" +
+	"\x0fGetPageResponse\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\"%\n" +
+	"\x0fJumpPageRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\"\x12\n" +
+	"\x10JumpPageResponse\"'\n" +
+	"\rPrintsRequest\x12\x16\n" +
+	"\x06target\x18\x01 \x01(\tR\x06target\"&\n" +
+	"\x0ePrintsResponse\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\"*\n" +
+	"\x10GetNumberRequest\x12\x16\n" +
+	"\x06target\x18\x01 \x01(\tR\x06target\")\n" +
+	"\x11GetNumberResponse\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x05R\x05value\"*\n" +
+	"\x10GetStringRequest\x12\x16\n" +
+	"\x06target\x18\x01 \x01(\tR\x06target\")\n" +
+	"\x11GetStringResponse\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\"@\n" +
+	"\fClickRequest\x12\x16\n" +
+	"\x06target\x18\x01 \x01(\tR\x06target\x12\x18\n" +
+	"\apressed\x18\x02 \x01(\bR\apressed\"\x0f\n" +
+	"\rClickResponse\"%\n" +
+	"\vShowRequest\x12\x16\n" +
+	"\x06target\x18\x01 \x01(\tR\x06target\"\x0e\n" +
+	"\fShowResponse\"%\n" +
+	"\vHideRequest\x12\x16\n" +
+	"\x06target\x18\x01 \x01(\tR\x06target\"\x0e\n" +
+	"\fHideResponse\"s\n" +
+	"\x0eUpgradeOptions\x12\x12\n" +
+	"\x04size\x18\x01 \x01(\x03R\x04size\x12\x1b\n" +
+	"\tbaud_rate\x18\x02 \x01(\x05R\bbaudRate\x12\x16\n" +
+	"\x06legacy\x18\x03 \x01(\bR\x06legacy\x12\x18\n" +
+	"\aretries\x18\x04 \x01(\x05R\aretries\"g\n" +
+	"\x0eUpgradeRequest\x122\n" +
+	"\aoptions\x18\x01 \x01(\v2\x16.tjc.v1.UpgradeOptionsH\x00R\aoptions\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\t\n" +
+	"\apayload\"\xb9\x01\n" +
+	"\x0fUpgradeResponse\x12\x18\n" +
+	"\acurrent\x18\x01 \x01(\x03R\acurrent\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x1e\n" +
+	"\n" +
+	"percentage\x18\x03 \x01(\x01R\n" +
+	"percentage\x12\x14\n" +
+	"\x05speed\x18\x04 \x01(\x03R\x05speed\x12\x1d\n" +
+	"\n" +
+	"elapsed_ms\x18\x05 \x01(\x03R\telapsedMs\x12!\n" +
+	"\fremaining_ms\x18\x06 \x01(\x03R\vremainingMs\"\x18\n" +
+	"\x16SubscribeEventsRequest\"\xe2\x01\n" +
+	"\x05Event\x12%\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x11.tjc.v1.EventTypeR\x04type\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\rR\x04code\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1c\n" +
+	"\tcomponent\x18\x04 \x01(\x05R\tcomponent\x12\x18\n" +
+	"\apressed\x18\x05 \x01(\bR\apressed\x12\f\n" +
+	"\x01x\x18\x06 \x01(\x05R\x01x\x12\f\n" +
+	"\x01y\x18\a \x01(\x05R\x01y\x12\x10\n" +
+	"\x03raw\x18\b \x01(\fR\x03raw\x12$\n" +
+	"\x0etime_unix_nano\x18\t \x01(\x03R\ftimeUnixNano\"\x18\n" +
+	"\x16SubscribeFramesRequest\"\x1b\n" +
+	"\x05Frame\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\";\n" +
+	"\vDeviceError\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\rR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"(\n" +
+	"\x0eUpgradeFailure\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x03R\x06offset*\xf7\x01\n" +
+	"\tEventType\x12\x1a\n" +
+	"\x16EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10EVENT_TYPE_TOUCH\x10\x01\x12\x13\n" +
+	"\x0fEVENT_TYPE_PAGE\x10\x02\x12\x1f\n" +
+	"\x1bEVENT_TYPE_TOUCH_COORDINATE\x10\x03\x12\x1a\n" +
+	"\x16EVENT_TYPE_SLEEP_TOUCH\x10\x04\x12\x19\n" +
+	"\x15EVENT_TYPE_AUTO_SLEEP\x10\x05\x12\x18\n" +
+	"\x14EVENT_TYPE_AUTO_WAKE\x10\x06\x12\x16\n" +
+	"\x12EVENT_TYPE_STARTUP\x10\a\x12\x19\n" +
+	"\x15EVENT_TYPE_SD_UPGRADE\x10\b2\xfc\x06\n" +
+	"\x0eDisplayService\x12A\n" +
+	"\rGetDeviceInfo\x12\x1c.tjc.v1.GetDeviceInfoRequest\x1a\x12.tjc.v1.DeviceInfo\x12O\n" +
+	"\x0eExecuteCommand\x12\x1d.tjc.v1.ExecuteCommandRequest\x1a\x1e.tjc.v1.ExecuteCommandResponse\x12:\n" +
+	"\aExecute\x12\x16.tjc.v1.ExecuteRequest\x1a\x17.tjc.v1.ExecuteResponse\x12:\n" +
+	"\aGetPage\x12\x16.tjc.v1.GetPageRequest\x1a\x17.tjc.v1.GetPageResponse\x12=\n" +
+	"\bJumpPage\x12\x17.tjc.v1.JumpPageRequest\x1a\x18.tjc.v1.JumpPageResponse\x127\n" +
+	"\x06Prints\x12\x15.tjc.v1.PrintsRequest\x1a\x16.tjc.v1.PrintsResponse\x12@\n" +
+	"\tGetNumber\x12\x18.tjc.v1.GetNumberRequest\x1a\x19.tjc.v1.GetNumberResponse\x12@\n" +
+	"\tGetString\x12\x18.tjc.v1.GetStringRequest\x1a\x19.tjc.v1.GetStringResponse\x124\n" +
+	"\x05Click\x12\x14.tjc.v1.ClickRequest\x1a\x15.tjc.v1.ClickResponse\x121\n" +
+	"\x04Show\x12\x13.tjc.v1.ShowRequest\x1a\x14.tjc.v1.ShowResponse\x121\n" +
+	"\x04Hide\x12\x13.tjc.v1.HideRequest\x1a\x14.tjc.v1.HideResponse\x12>\n" +
+	"\aUpgrade\x12\x16.tjc.v1.UpgradeRequest\x1a\x17.tjc.v1.UpgradeResponse(\x010\x01\x12B\n" +
+	"\x0fSubscribeEvents\x12\x1e.tjc.v1.SubscribeEventsRequest\x1a\r.tjc.v1.Event0\x01\x12B\n" +
+	"\x0fSubscribeFrames\x12\x1e.tjc.v1.SubscribeFramesRequest\x1a\r.tjc.v1.Frame0\x01BCZAgithub.com/blue-cloud-net/tjc-serial-display/pkg/remote/displaypbb\x06proto3"
+
+var (
+	file_tjc_v1_display_proto_rawDescOnce sync.Once
+	file_tjc_v1_display_proto_rawDescData []byte
+)
+
+func file_tjc_v1_display_proto_rawDescGZIP() []byte {
+	file_tjc_v1_display_proto_rawDescOnce.Do(func() {
+		file_tjc_v1_display_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_tjc_v1_display_proto_rawDesc), len(file_tjc_v1_display_proto_rawDesc)))
+	})
+	return file_tjc_v1_display_proto_rawDescData
+}
+
+var file_tjc_v1_display_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_tjc_v1_display_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
+var file_tjc_v1_display_proto_goTypes = []any{
+	(EventType)(0),                 // 0: tjc.v1.EventType
+	(*GetDeviceInfoRequest)(nil),   // 1: tjc.v1.GetDeviceInfoRequest
+	(*DeviceInfo)(nil),             // 2: tjc.v1.DeviceInfo
+	(*ExecuteCommandRequest)(nil),  // 3: tjc.v1.ExecuteCommandRequest
+	(*ExecuteCommandResponse)(nil), // 4: tjc.v1.ExecuteCommandResponse
+	(*ExecuteRequest)(nil),         // 5: tjc.v1.ExecuteRequest
+	(*ExecuteResponse)(nil),        // 6: tjc.v1.ExecuteResponse
+	(*GetPageRequest)(nil),         // 7: tjc.v1.GetPageRequest
+	(*GetPageResponse)(nil),        // 8: tjc.v1.GetPageResponse
+	(*JumpPageRequest)(nil),        // 9: tjc.v1.JumpPageRequest
+	(*JumpPageResponse)(nil),       // 10: tjc.v1.JumpPageResponse
+	(*PrintsRequest)(nil),          // 11: tjc.v1.PrintsRequest
+	(*PrintsResponse)(nil),         // 12: tjc.v1.PrintsResponse
+	(*GetNumberRequest)(nil),       // 13: tjc.v1.GetNumberRequest
+	(*GetNumberResponse)(nil),      // 14: tjc.v1.GetNumberResponse
+	(*GetStringRequest)(nil),       // 15: tjc.v1.GetStringRequest
+	(*GetStringResponse)(nil),      // 16: tjc.v1.GetStringResponse
+	(*ClickRequest)(nil),           // 17: tjc.v1.ClickRequest
+	(*ClickResponse)(nil),          // 18: tjc.v1.ClickResponse
+	(*ShowRequest)(nil),            // 19: tjc.v1.ShowRequest
+	(*ShowResponse)(nil),           // 20: tjc.v1.ShowResponse
+	(*HideRequest)(nil),            // 21: tjc.v1.HideRequest
+	(*HideResponse)(nil),           // 22: tjc.v1.HideResponse
+	(*UpgradeOptions)(nil),         // 23: tjc.v1.UpgradeOptions
+	(*UpgradeRequest)(nil),         // 24: tjc.v1.UpgradeRequest
+	(*UpgradeResponse)(nil),        // 25: tjc.v1.UpgradeResponse
+	(*SubscribeEventsRequest)(nil), // 26: tjc.v1.SubscribeEventsRequest
+	(*Event)(nil),                  // 27: tjc.v1.Event
+	(*SubscribeFramesRequest)(nil), // 28: tjc.v1.SubscribeFramesRequest
+	(*Frame)(nil),                  // 29: tjc.v1.Frame
+	(*DeviceError)(nil),            // 30: tjc.v1.DeviceError
+	(*UpgradeFailure)(nil),         // 31: tjc.v1.UpgradeFailure
+}
+var file_tjc_v1_display_proto_depIdxs = []int32{
+	23, // 0: tjc.v1.UpgradeRequest.options:type_name -> tjc.v1.UpgradeOptions
+	0,  // 1: tjc.v1.Event.type:type_name -> tjc.v1.EventType
+	1,  // 2: tjc.v1.DisplayService.GetDeviceInfo:input_type -> tjc.v1.GetDeviceInfoRequest
+	3,  // 3: tjc.v1.DisplayService.ExecuteCommand:input_type -> tjc.v1.ExecuteCommandRequest
+	5,  // 4: tjc.v1.DisplayService.Execute:input_type -> tjc.v1.ExecuteRequest
+	7,  // 5: tjc.v1.DisplayService.GetPage:input_type -> tjc.v1.GetPageRequest
+	9,  // 6: tjc.v1.DisplayService.JumpPage:input_type -> tjc.v1.JumpPageRequest
+	11, // 7: tjc.v1.DisplayService.Prints:input_type -> tjc.v1.PrintsRequest
+	13, // 8: tjc.v1.DisplayService.GetNumber:input_type -> tjc.v1.GetNumberRequest
+	15, // 9: tjc.v1.DisplayService.GetString:input_type -> tjc.v1.GetStringRequest
+	17, // 10: tjc.v1.DisplayService.Click:input_type -> tjc.v1.ClickRequest
+	19, // 11: tjc.v1.DisplayService.Show:input_type -> tjc.v1.ShowRequest
+	21, // 12: tjc.v1.DisplayService.Hide:input_type -> tjc.v1.HideRequest
+	24, // 13: tjc.v1.DisplayService.Upgrade:input_type -> tjc.v1.UpgradeRequest
+	26, // 14: tjc.v1.DisplayService.SubscribeEvents:input_type -> tjc.v1.SubscribeEventsRequest
+	28, // 15: tjc.v1.DisplayService.SubscribeFrames:input_type -> tjc.v1.SubscribeFramesRequest
+	2,  // 16: tjc.v1.DisplayService.GetDeviceInfo:output_type -> tjc.v1.DeviceInfo
+	4,  // 17: tjc.v1.DisplayService.ExecuteCommand:output_type -> tjc.v1.ExecuteCommandResponse
+	6,  // 18: tjc.v1.DisplayService.Execute:output_type -> tjc.v1.ExecuteResponse
+	8,  // 19: tjc.v1.DisplayService.GetPage:output_type -> tjc.v1.GetPageResponse
+	10, // 20: tjc.v1.DisplayService.JumpPage:output_type -> tjc.v1.JumpPageResponse
+	12, // 21: tjc.v1.DisplayService.Prints:output_type -> tjc.v1.PrintsResponse
+	14, // 22: tjc.v1.DisplayService.GetNumber:output_type -> tjc.v1.GetNumberResponse
+	16, // 23: tjc.v1.DisplayService.GetString:output_type -> tjc.v1.GetStringResponse
+	18, // 24: tjc.v1.DisplayService.Click:output_type -> tjc.v1.ClickResponse
+	20, // 25: tjc.v1.DisplayService.Show:output_type -> tjc.v1.ShowResponse
+	22, // 26: tjc.v1.DisplayService.Hide:output_type -> tjc.v1.HideResponse
+	25, // 27: tjc.v1.DisplayService.Upgrade:output_type -> tjc.v1.UpgradeResponse
+	27, // 28: tjc.v1.DisplayService.SubscribeEvents:output_type -> tjc.v1.Event
+	29, // 29: tjc.v1.DisplayService.SubscribeFrames:output_type -> tjc.v1.Frame
+	16, // [16:30] is the sub-list for method output_type
+	2,  // [2:16] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_tjc_v1_display_proto_init() }
+func file_tjc_v1_display_proto_init() {
+	if File_tjc_v1_display_proto != nil {
+		return
+	}
+	file_tjc_v1_display_proto_msgTypes[23].OneofWrappers = []any{
+		(*UpgradeRequest_Options)(nil),
+		(*UpgradeRequest_Chunk)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_tjc_v1_display_proto_rawDesc), len(file_tjc_v1_display_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   31,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_tjc_v1_display_proto_goTypes,
+		DependencyIndexes: file_tjc_v1_display_proto_depIdxs,
+		EnumInfos:         file_tjc_v1_display_proto_enumTypes,
+		MessageInfos:      file_tjc_v1_display_proto_msgTypes,
+	}.Build()
+	File_tjc_v1_display_proto = out.File
+	file_tjc_v1_display_proto_goTypes = nil
+	file_tjc_v1_display_proto_depIdxs = nil
+}
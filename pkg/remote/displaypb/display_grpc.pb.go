@@ -0,0 +1,646 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: tjc/v1/display.proto
+
+// 显示屏远程控制服务，与 pkg/client.DisplayClient 接口一一对应
+
+package displaypb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	DisplayService_GetDeviceInfo_FullMethodName   = "/tjc.v1.DisplayService/GetDeviceInfo"
+	DisplayService_ExecuteCommand_FullMethodName  = "/tjc.v1.DisplayService/ExecuteCommand"
+	DisplayService_Execute_FullMethodName         = "/tjc.v1.DisplayService/Execute"
+	DisplayService_GetPage_FullMethodName         = "/tjc.v1.DisplayService/GetPage"
+	DisplayService_JumpPage_FullMethodName        = "/tjc.v1.DisplayService/JumpPage"
+	DisplayService_Prints_FullMethodName          = "/tjc.v1.DisplayService/Prints"
+	DisplayService_GetNumber_FullMethodName       = "/tjc.v1.DisplayService/GetNumber"
+	DisplayService_GetString_FullMethodName       = "/tjc.v1.DisplayService/GetString"
+	DisplayService_Click_FullMethodName           = "/tjc.v1.DisplayService/Click"
+	DisplayService_Show_FullMethodName            = "/tjc.v1.DisplayService/Show"
+	DisplayService_Hide_FullMethodName            = "/tjc.v1.DisplayService/Hide"
+	DisplayService_Upgrade_FullMethodName         = "/tjc.v1.DisplayService/Upgrade"
+	DisplayService_SubscribeEvents_FullMethodName = "/tjc.v1.DisplayService/SubscribeEvents"
+	DisplayService_SubscribeFrames_FullMethodName = "/tjc.v1.DisplayService/SubscribeFrames"
+)
+
+// DisplayServiceClient is the client API for DisplayService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DisplayServiceClient interface {
+	// 获取设备信息
+	GetDeviceInfo(ctx context.Context, in *GetDeviceInfoRequest, opts ...grpc.CallOption) (*DeviceInfo, error)
+	// 执行原始 TJC 命令，返回设备的原始应答
+	ExecuteCommand(ctx context.Context, in *ExecuteCommandRequest, opts ...grpc.CallOption) (*ExecuteCommandResponse, error)
+	// 执行指令并检查设备返回的错误码
+	Execute(ctx context.Context, in *ExecuteRequest, opts ...grpc.CallOption) (*ExecuteResponse, error)
+	// 获取当前页面
+	GetPage(ctx context.Context, in *GetPageRequest, opts ...grpc.CallOption) (*GetPageResponse, error)
+	// 跳转到指定页面
+	JumpPage(ctx context.Context, in *JumpPageRequest, opts ...grpc.CallOption) (*JumpPageResponse, error)
+	// 打印目标的值或者输入内容
+	Prints(ctx context.Context, in *PrintsRequest, opts ...grpc.CallOption) (*PrintsResponse, error)
+	// 获取目标的数值，如 n0.val
+	GetNumber(ctx context.Context, in *GetNumberRequest, opts ...grpc.CallOption) (*GetNumberResponse, error)
+	// 获取目标的字符串，如 t0.txt
+	GetString(ctx context.Context, in *GetStringRequest, opts ...grpc.CallOption) (*GetStringResponse, error)
+	// 模拟按下或弹起目标按钮
+	Click(ctx context.Context, in *ClickRequest, opts ...grpc.CallOption) (*ClickResponse, error)
+	// 显示指定目标
+	Show(ctx context.Context, in *ShowRequest, opts ...grpc.CallOption) (*ShowResponse, error)
+	// 隐藏指定目标
+	Hide(ctx context.Context, in *HideRequest, opts ...grpc.CallOption) (*HideResponse, error)
+	// 升级面板程序：先发送选项，再分块发送程序文件，服务端在升级过程中持续返回进度
+	Upgrade(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[UpgradeRequest, UpgradeResponse], error)
+	// 订阅设备主动上报的事件
+	SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+	// 订阅串口收到的每一帧原始数据
+	SubscribeFrames(ctx context.Context, in *SubscribeFramesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Frame], error)
+}
+
+type displayServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDisplayServiceClient(cc grpc.ClientConnInterface) DisplayServiceClient {
+	return &displayServiceClient{cc}
+}
+
+func (c *displayServiceClient) GetDeviceInfo(ctx context.Context, in *GetDeviceInfoRequest, opts ...grpc.CallOption) (*DeviceInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeviceInfo)
+	err := c.cc.Invoke(ctx, DisplayService_GetDeviceInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *displayServiceClient) ExecuteCommand(ctx context.Context, in *ExecuteCommandRequest, opts ...grpc.CallOption) (*ExecuteCommandResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExecuteCommandResponse)
+	err := c.cc.Invoke(ctx, DisplayService_ExecuteCommand_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *displayServiceClient) Execute(ctx context.Context, in *ExecuteRequest, opts ...grpc.CallOption) (*ExecuteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExecuteResponse)
+	err := c.cc.Invoke(ctx, DisplayService_Execute_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *displayServiceClient) GetPage(ctx context.Context, in *GetPageRequest, opts ...grpc.CallOption) (*GetPageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPageResponse)
+	err := c.cc.Invoke(ctx, DisplayService_GetPage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *displayServiceClient) JumpPage(ctx context.Context, in *JumpPageRequest, opts ...grpc.CallOption) (*JumpPageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JumpPageResponse)
+	err := c.cc.Invoke(ctx, DisplayService_JumpPage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *displayServiceClient) Prints(ctx context.Context, in *PrintsRequest, opts ...grpc.CallOption) (*PrintsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PrintsResponse)
+	err := c.cc.Invoke(ctx, DisplayService_Prints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *displayServiceClient) GetNumber(ctx context.Context, in *GetNumberRequest, opts ...grpc.CallOption) (*GetNumberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNumberResponse)
+	err := c.cc.Invoke(ctx, DisplayService_GetNumber_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *displayServiceClient) GetString(ctx context.Context, in *GetStringRequest, opts ...grpc.CallOption) (*GetStringResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStringResponse)
+	err := c.cc.Invoke(ctx, DisplayService_GetString_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *displayServiceClient) Click(ctx context.Context, in *ClickRequest, opts ...grpc.CallOption) (*ClickResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClickResponse)
+	err := c.cc.Invoke(ctx, DisplayService_Click_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *displayServiceClient) Show(ctx context.Context, in *ShowRequest, opts ...grpc.CallOption) (*ShowResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShowResponse)
+	err := c.cc.Invoke(ctx, DisplayService_Show_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *displayServiceClient) Hide(ctx context.Context, in *HideRequest, opts ...grpc.CallOption) (*HideResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HideResponse)
+	err := c.cc.Invoke(ctx, DisplayService_Hide_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *displayServiceClient) Upgrade(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[UpgradeRequest, UpgradeResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DisplayService_ServiceDesc.Streams[0], DisplayService_Upgrade_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UpgradeRequest, UpgradeResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DisplayService_UpgradeClient = grpc.BidiStreamingClient[UpgradeRequest, UpgradeResponse]
+
+func (c *displayServiceClient) SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DisplayService_ServiceDesc.Streams[1], DisplayService_SubscribeEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeEventsRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DisplayService_SubscribeEventsClient = grpc.ServerStreamingClient[Event]
+
+func (c *displayServiceClient) SubscribeFrames(ctx context.Context, in *SubscribeFramesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Frame], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DisplayService_ServiceDesc.Streams[2], DisplayService_SubscribeFrames_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeFramesRequest, Frame]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DisplayService_SubscribeFramesClient = grpc.ServerStreamingClient[Frame]
+
+// DisplayServiceServer is the server API for DisplayService service.
+// All implementations must embed UnimplementedDisplayServiceServer
+// for forward compatibility.
+type DisplayServiceServer interface {
+	// 获取设备信息
+	GetDeviceInfo(context.Context, *GetDeviceInfoRequest) (*DeviceInfo, error)
+	// 执行原始 TJC 命令，返回设备的原始应答
+	ExecuteCommand(context.Context, *ExecuteCommandRequest) (*ExecuteCommandResponse, error)
+	// 执行指令并检查设备返回的错误码
+	Execute(context.Context, *ExecuteRequest) (*ExecuteResponse, error)
+	// 获取当前页面
+	GetPage(context.Context, *GetPageRequest) (*GetPageResponse, error)
+	// 跳转到指定页面
+	JumpPage(context.Context, *JumpPageRequest) (*JumpPageResponse, error)
+	// 打印目标的值或者输入内容
+	Prints(context.Context, *PrintsRequest) (*PrintsResponse, error)
+	// 获取目标的数值，如 n0.val
+	GetNumber(context.Context, *GetNumberRequest) (*GetNumberResponse, error)
+	// 获取目标的字符串，如 t0.txt
+	GetString(context.Context, *GetStringRequest) (*GetStringResponse, error)
+	// 模拟按下或弹起目标按钮
+	Click(context.Context, *ClickRequest) (*ClickResponse, error)
+	// 显示指定目标
+	Show(context.Context, *ShowRequest) (*ShowResponse, error)
+	// 隐藏指定目标
+	Hide(context.Context, *HideRequest) (*HideResponse, error)
+	// 升级面板程序：先发送选项，再分块发送程序文件，服务端在升级过程中持续返回进度
+	Upgrade(grpc.BidiStreamingServer[UpgradeRequest, UpgradeResponse]) error
+	// 订阅设备主动上报的事件
+	SubscribeEvents(*SubscribeEventsRequest, grpc.ServerStreamingServer[Event]) error
+	// 订阅串口收到的每一帧原始数据
+	SubscribeFrames(*SubscribeFramesRequest, grpc.ServerStreamingServer[Frame]) error
+	mustEmbedUnimplementedDisplayServiceServer()
+}
+
+// UnimplementedDisplayServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDisplayServiceServer struct{}
+
+func (UnimplementedDisplayServiceServer) GetDeviceInfo(context.Context, *GetDeviceInfoRequest) (*DeviceInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDeviceInfo not implemented")
+}
+func (UnimplementedDisplayServiceServer) ExecuteCommand(context.Context, *ExecuteCommandRequest) (*ExecuteCommandResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExecuteCommand not implemented")
+}
+func (UnimplementedDisplayServiceServer) Execute(context.Context, *ExecuteRequest) (*ExecuteResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Execute not implemented")
+}
+func (UnimplementedDisplayServiceServer) GetPage(context.Context, *GetPageRequest) (*GetPageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPage not implemented")
+}
+func (UnimplementedDisplayServiceServer) JumpPage(context.Context, *JumpPageRequest) (*JumpPageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method JumpPage not implemented")
+}
+func (UnimplementedDisplayServiceServer) Prints(context.Context, *PrintsRequest) (*PrintsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Prints not implemented")
+}
+func (UnimplementedDisplayServiceServer) GetNumber(context.Context, *GetNumberRequest) (*GetNumberResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetNumber not implemented")
+}
+func (UnimplementedDisplayServiceServer) GetString(context.Context, *GetStringRequest) (*GetStringResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetString not implemented")
+}
+func (UnimplementedDisplayServiceServer) Click(context.Context, *ClickRequest) (*ClickResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Click not implemented")
+}
+func (UnimplementedDisplayServiceServer) Show(context.Context, *ShowRequest) (*ShowResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Show not implemented")
+}
+func (UnimplementedDisplayServiceServer) Hide(context.Context, *HideRequest) (*HideResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Hide not implemented")
+}
+func (UnimplementedDisplayServiceServer) Upgrade(grpc.BidiStreamingServer[UpgradeRequest, UpgradeResponse]) error {
+	return status.Error(codes.Unimplemented, "method Upgrade not implemented")
+}
+func (UnimplementedDisplayServiceServer) SubscribeEvents(*SubscribeEventsRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Error(codes.Unimplemented, "method SubscribeEvents not implemented")
+}
+func (UnimplementedDisplayServiceServer) SubscribeFrames(*SubscribeFramesRequest, grpc.ServerStreamingServer[Frame]) error {
+	return status.Error(codes.Unimplemented, "method SubscribeFrames not implemented")
+}
+func (UnimplementedDisplayServiceServer) mustEmbedUnimplementedDisplayServiceServer() {}
+func (UnimplementedDisplayServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeDisplayServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DisplayServiceServer will
+// result in compilation errors.
+type UnsafeDisplayServiceServer interface {
+	mustEmbedUnimplementedDisplayServiceServer()
+}
+
+func RegisterDisplayServiceServer(s grpc.ServiceRegistrar, srv DisplayServiceServer) {
+	// If the following call panics, it indicates UnimplementedDisplayServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DisplayService_ServiceDesc, srv)
+}
+
+func _DisplayService_GetDeviceInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisplayServiceServer).GetDeviceInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisplayService_GetDeviceInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisplayServiceServer).GetDeviceInfo(ctx, req.(*GetDeviceInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisplayService_ExecuteCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteCommandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisplayServiceServer).ExecuteCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisplayService_ExecuteCommand_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisplayServiceServer).ExecuteCommand(ctx, req.(*ExecuteCommandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisplayService_Execute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisplayServiceServer).Execute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisplayService_Execute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisplayServiceServer).Execute(ctx, req.(*ExecuteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisplayService_GetPage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisplayServiceServer).GetPage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisplayService_GetPage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisplayServiceServer).GetPage(ctx, req.(*GetPageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisplayService_JumpPage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JumpPageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisplayServiceServer).JumpPage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisplayService_JumpPage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisplayServiceServer).JumpPage(ctx, req.(*JumpPageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisplayService_Prints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrintsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisplayServiceServer).Prints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisplayService_Prints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisplayServiceServer).Prints(ctx, req.(*PrintsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisplayService_GetNumber_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNumberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisplayServiceServer).GetNumber(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisplayService_GetNumber_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisplayServiceServer).GetNumber(ctx, req.(*GetNumberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisplayService_GetString_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStringRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisplayServiceServer).GetString(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisplayService_GetString_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisplayServiceServer).GetString(ctx, req.(*GetStringRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisplayService_Click_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClickRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisplayServiceServer).Click(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisplayService_Click_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisplayServiceServer).Click(ctx, req.(*ClickRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisplayService_Show_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisplayServiceServer).Show(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisplayService_Show_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisplayServiceServer).Show(ctx, req.(*ShowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisplayService_Hide_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisplayServiceServer).Hide(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisplayService_Hide_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisplayServiceServer).Hide(ctx, req.(*HideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisplayService_Upgrade_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DisplayServiceServer).Upgrade(&grpc.GenericServerStream[UpgradeRequest, UpgradeResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DisplayService_UpgradeServer = grpc.BidiStreamingServer[UpgradeRequest, UpgradeResponse]
+
+func _DisplayService_SubscribeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DisplayServiceServer).SubscribeEvents(m, &grpc.GenericServerStream[SubscribeEventsRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DisplayService_SubscribeEventsServer = grpc.ServerStreamingServer[Event]
+
+func _DisplayService_SubscribeFrames_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeFramesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DisplayServiceServer).SubscribeFrames(m, &grpc.GenericServerStream[SubscribeFramesRequest, Frame]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DisplayService_SubscribeFramesServer = grpc.ServerStreamingServer[Frame]
+
+// DisplayService_ServiceDesc is the grpc.ServiceDesc for DisplayService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DisplayService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "tjc.v1.DisplayService",
+	HandlerType: (*DisplayServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetDeviceInfo",
+			Handler:    _DisplayService_GetDeviceInfo_Handler,
+		},
+		{
+			MethodName: "ExecuteCommand",
+			Handler:    _DisplayService_ExecuteCommand_Handler,
+		},
+		{
+			MethodName: "Execute",
+			Handler:    _DisplayService_Execute_Handler,
+		},
+		{
+			MethodName: "GetPage",
+			Handler:    _DisplayService_GetPage_Handler,
+		},
+		{
+			MethodName: "JumpPage",
+			Handler:    _DisplayService_JumpPage_Handler,
+		},
+		{
+			MethodName: "Prints",
+			Handler:    _DisplayService_Prints_Handler,
+		},
+		{
+			MethodName: "GetNumber",
+			Handler:    _DisplayService_GetNumber_Handler,
+		},
+		{
+			MethodName: "GetString",
+			Handler:    _DisplayService_GetString_Handler,
+		},
+		{
+			MethodName: "Click",
+			Handler:    _DisplayService_Click_Handler,
+		},
+		{
+			MethodName: "Show",
+			Handler:    _DisplayService_Show_Handler,
+		},
+		{
+			MethodName: "Hide",
+			Handler:    _DisplayService_Hide_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Upgrade",
+			Handler:       _DisplayService_Upgrade_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "SubscribeEvents",
+			Handler:       _DisplayService_SubscribeEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeFrames",
+			Handler:       _DisplayService_SubscribeFrames_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "tjc/v1/display.proto",
+}
@@ -0,0 +1,260 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	internalclient "github.com/blue-cloud-net/tjc-serial-display/internal/client"
+	"github.com/blue-cloud-net/tjc-serial-display/internal/simulator"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/client"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/components"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newRemoteClient 启动包装模拟设备的 gRPC 服务，返回连接该服务的远程客户端
+func newRemoteClient(t *testing.T, device *simulator.Device) *Client {
+	t.Helper()
+
+	remote, _ := startRemote(t, device)
+	return remote
+}
+
+// startRemote 启动包装模拟设备的 gRPC 服务，返回远程客户端和服务器
+func startRemote(t *testing.T, device *simulator.Device) (*Client, *grpc.Server) {
+	t.Helper()
+
+	local := &internalclient.TjcDisplayClient{
+		PortName: "sim",
+		BaudRate: 115200,
+		Timeout:  200 * time.Millisecond,
+		Opener:   device.Opener(),
+	}
+	if err := local.Open(); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	t.Cleanup(func() { local.Close() })
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	NewServer(local).Register(server)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	remote, err := Dial("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	t.Cleanup(func() { remote.Close() })
+
+	return remote, server
+}
+
+func TestClient_Operations(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	device.AddPage("page1")
+	device.AddComponent(0, 1, "t0", map[string]any{"txt": "hello"})
+	device.AddComponent(0, 2, "b0", nil)
+
+	// 远程客户端与本地客户端使用同一个接口
+	var display client.ExtendedClient = newRemoteClient(t, device)
+
+	info, err := display.GetDeviceInfo()
+	if err != nil {
+		t.Fatalf("GetDeviceInfo failed: %v", err)
+	}
+	if info.Model != "TJC4024T032_011R" || info.FlashSize != 16777216 {
+		t.Errorf("Unexpected device info: %+v", info)
+	}
+
+	if err := display.Text("t0").Set("world"); err != nil {
+		t.Fatalf("Set text failed: %v", err)
+	}
+	if text, err := display.Text("t0").Get(); err != nil || text != "world" {
+		t.Errorf("Expected world, got %q, %v", text, err)
+	}
+
+	if err := display.Hide("b0"); err != nil {
+		t.Fatalf("Hide failed: %v", err)
+	}
+	if device.Component(0, "b0").Visible {
+		t.Error("Expected b0 to be hidden")
+	}
+
+	result, err := display.ExecuteCommand("sendme")
+	if err != nil || !bytes.Equal(result, []byte{0x66, 0x00, 0xFF, 0xFF, 0xFF}) {
+		t.Errorf("Unexpected sendme result % X, %v", result, err)
+	}
+
+	if err := display.JumpPage(1); err != nil {
+		t.Fatalf("JumpPage failed: %v", err)
+	}
+	if page, err := display.GetPage(); err != nil || page != 1 {
+		t.Errorf("Expected page 1, got %d, %v", page, err)
+	}
+}
+
+func TestClient_Errors(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	display := newRemoteClient(t, device)
+
+	// 设备错误码还原为 TjcError
+	err := display.JumpPage(9)
+	var tjcErr *client.TjcError
+	if !errors.As(err, &tjcErr) || tjcErr.Code != 0x03 {
+		t.Errorf("Expected TjcError 0x03, got %v", err)
+	}
+
+	// 控件句柄据此映射为控件错误
+	_, err = display.Text("t9").Get()
+	if !errors.Is(err, components.ErrInvalidAttribute) && !errors.Is(err, components.ErrInvalidComponent) {
+		t.Errorf("Expected component error, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := display.GetDeviceInfoContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestClient_Subscribe(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	display := newRemoteClient(t, device)
+
+	events, sub, err := display.Events(4)
+	if err != nil {
+		t.Fatalf("Events failed: %v", err)
+	}
+	defer sub.Cancel()
+
+	device.Touch(0, 2, true)
+
+	select {
+	case event := <-events:
+		if event.Type != models.EventTouch || event.Component != 2 || !event.Pressed {
+			t.Errorf("Unexpected event: %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for event")
+	}
+}
+
+func TestClient_SubscriptionErrors(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	display, server := startRemote(t, device)
+
+	events, sub, err := display.Events(4)
+	if err != nil {
+		t.Fatalf("Events failed: %v", err)
+	}
+
+	// 服务器停止后订阅结束，通道关闭，Err 返回原因
+	server.Stop()
+	select {
+	case <-sub.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for subscription to end")
+	}
+	if sub.Err() == nil {
+		t.Error("Expected error after server stopped")
+	}
+	if _, ok := <-events; ok {
+		t.Error("Expected events channel to be closed")
+	}
+
+	// 无法建立订阅时直接返回错误
+	display.Close()
+	if _, err := display.SubscribeFrames(func([]byte) {}); err == nil {
+		t.Error("Expected error subscribing on a closed connection")
+	}
+}
+
+func TestClient_SubscriptionCancel(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	display := newRemoteClient(t, device)
+
+	sub, err := display.SubscribeFrames(func([]byte) {})
+	if err != nil {
+		t.Fatalf("SubscribeFrames failed: %v", err)
+	}
+
+	sub.Cancel()
+	select {
+	case <-sub.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for subscription to end")
+	}
+	if sub.Err() != nil {
+		t.Errorf("Expected no error after Cancel, got %v", sub.Err())
+	}
+}
+
+func writeProgram(t *testing.T, size int) (string, []byte) {
+	t.Helper()
+
+	program := make([]byte, size)
+	copy(program[16:], "TJC4024T032_011R")
+
+	path := filepath.Join(t.TempDir(), "program.tft")
+	if err := os.WriteFile(path, program, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path, program
+}
+
+func TestClient_Upgrade(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	display := newRemoteClient(t, device)
+	path, program := writeProgram(t, 150000)
+
+	var last *models.UpgradeProgress
+	err := display.Upgrade(path, 0, func(progress *models.UpgradeProgress) {
+		last = progress
+	})
+	if err != nil {
+		t.Fatalf("Upgrade failed: %v", err)
+	}
+
+	if last == nil || last.Current != 150000 || last.Total != 150000 {
+		t.Errorf("Unexpected final progress: %+v", last)
+	}
+	if !bytes.Equal(device.UpgradeData(), program) {
+		t.Error("Expected device to receive the whole program")
+	}
+
+	// 服务端总是检查型号，不能跳过
+	if err := display.UpgradeWithOptions(path, &models.UpgradeOptions{Force: true}); !errors.Is(err, errForceUnsupported) {
+		t.Errorf("Expected errForceUnsupported, got %v", err)
+	}
+}
+
+func TestClient_UpgradeInterrupted(t *testing.T) {
+	device := simulator.New(simulator.Config{ReturnMode: 3})
+	device.InterruptUpgrade(10000)
+	display := newRemoteClient(t, device)
+	path, _ := writeProgram(t, 20000)
+
+	err := display.UpgradeWithOptions(path, &models.UpgradeOptions{Retries: -1})
+	var upgradeErr *client.UpgradeError
+	if !errors.As(err, &upgradeErr) {
+		t.Fatalf("Expected UpgradeError, got %v", err)
+	}
+	if upgradeErr.Offset != 8192 {
+		t.Errorf("Expected interruption at byte 8192, got %d", upgradeErr.Offset)
+	}
+}
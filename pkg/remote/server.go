@@ -0,0 +1,328 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/client"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/models"
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/remote/displaypb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// 订阅流的缓冲大小，客户端接收过慢时丢弃新数据
+const streamBufferSize = 64
+
+// Server 将显示屏客户端包装为 gRPC 服务（tjc.v1.DisplayService）
+type Server struct {
+	displaypb.UnimplementedDisplayServiceServer
+
+	Client  client.LocalClient // 本地显示屏客户端，如 TjcDisplayClient
+	TempDir string             // 上传的程序文件存放目录，为空时使用系统临时目录
+}
+
+// NewServer 创建包装 c 的 gRPC 服务
+func NewServer(c client.LocalClient) *Server {
+	return &Server{Client: c}
+}
+
+// Register 将服务注册到 gRPC 服务器
+func (s *Server) Register(registrar grpc.ServiceRegistrar) {
+	displaypb.RegisterDisplayServiceServer(registrar, s)
+}
+
+func (s *Server) GetDeviceInfo(ctx context.Context, _ *displaypb.GetDeviceInfoRequest) (*displaypb.DeviceInfo, error) {
+	info, err := s.Client.GetDeviceInfoContext(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &displaypb.DeviceInfo{
+		Type:                  int32(info.Type),
+		Address:               info.Address,
+		Model:                 info.Model,
+		FirmwareVersion:       int32(info.FirmwareVersion),
+		MainControlChipNumber: int32(info.MainControlChipNumber),
+		Number:                info.Number,
+		FlashSize:             int64(info.FlashSize),
+	}, nil
+}
+
+func (s *Server) ExecuteCommand(ctx context.Context, req *displaypb.ExecuteCommandRequest) (*displaypb.ExecuteCommandResponse, error) {
+	result, err := s.Client.ExecuteCommandContext(ctx, req.GetCommand())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &displaypb.ExecuteCommandResponse{Result: result}, nil
+}
+
+func (s *Server) Execute(_ context.Context, req *displaypb.ExecuteRequest) (*displaypb.ExecuteResponse, error) {
+	err := s.Client.Execute(req.GetInstruction())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &displaypb.ExecuteResponse{}, nil
+}
+
+func (s *Server) GetPage(_ context.Context, _ *displaypb.GetPageRequest) (*displaypb.GetPageResponse, error) {
+	page, err := s.Client.GetPage()
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &displaypb.GetPageResponse{Page: int32(page)}, nil
+}
+
+func (s *Server) JumpPage(_ context.Context, req *displaypb.JumpPageRequest) (*displaypb.JumpPageResponse, error) {
+	err := s.Client.JumpPage(int(req.GetPage()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &displaypb.JumpPageResponse{}, nil
+}
+
+func (s *Server) Prints(_ context.Context, req *displaypb.PrintsRequest) (*displaypb.PrintsResponse, error) {
+	value, err := s.Client.Prints(req.GetTarget())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &displaypb.PrintsResponse{Value: value}, nil
+}
+
+func (s *Server) GetNumber(_ context.Context, req *displaypb.GetNumberRequest) (*displaypb.GetNumberResponse, error) {
+	value, err := s.Client.GetNumber(req.GetTarget())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &displaypb.GetNumberResponse{Value: value}, nil
+}
+
+func (s *Server) GetString(_ context.Context, req *displaypb.GetStringRequest) (*displaypb.GetStringResponse, error) {
+	value, err := s.Client.GetString(req.GetTarget())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &displaypb.GetStringResponse{Value: value}, nil
+}
+
+func (s *Server) Click(_ context.Context, req *displaypb.ClickRequest) (*displaypb.ClickResponse, error) {
+	var err error
+	if req.GetPressed() {
+		err = s.Client.ClickDown(req.GetTarget())
+	} else {
+		err = s.Client.ClickUp(req.GetTarget())
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &displaypb.ClickResponse{}, nil
+}
+
+func (s *Server) Show(_ context.Context, req *displaypb.ShowRequest) (*displaypb.ShowResponse, error) {
+	err := s.Client.Show(req.GetTarget())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &displaypb.ShowResponse{}, nil
+}
+
+func (s *Server) Hide(_ context.Context, req *displaypb.HideRequest) (*displaypb.HideResponse, error) {
+	err := s.Client.Hide(req.GetTarget())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &displaypb.HideResponse{}, nil
+}
+
+// Upgrade 接收完整的程序文件后开始升级，升级过程中持续返回进度
+func (s *Server) Upgrade(stream displaypb.DisplayService_UpgradeServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	options := first.GetOptions()
+	if options == nil {
+		return status.Error(codes.InvalidArgument, "first upgrade message must carry options")
+	}
+
+	f, err := os.CreateTemp(s.TempDir, "tjc-upgrade-*.tft")
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer os.Remove(f.Name())
+
+	received, err := receiveProgram(stream, f)
+	f.Close()
+	if err != nil {
+		return err
+	}
+	if received != options.GetSize() {
+		return status.Errorf(codes.InvalidArgument, "received %d bytes, expected %d", received, options.GetSize())
+	}
+
+	err = s.Client.UpgradeContext(stream.Context(), f.Name(), &models.UpgradeOptions{
+		BaudRate: int(options.GetBaudRate()),
+		Legacy:   options.GetLegacy(),
+		Retries:  int(options.GetRetries()),
+		Progress: func(progress *models.UpgradeProgress) {
+			_ = stream.Send(&displaypb.UpgradeResponse{
+				Current:     progress.Current,
+				Total:       progress.Total,
+				Percentage:  progress.Percentage,
+				Speed:       progress.Speed,
+				ElapsedMs:   progress.Elapsed.Milliseconds(),
+				RemainingMs: progress.Remaining.Milliseconds(),
+			})
+		},
+	})
+
+	return toStatus(err)
+}
+
+// receiveProgram 将后续消息中的程序文件内容写入 w，返回接收的字节数
+func receiveProgram(stream displaypb.DisplayService_UpgradeServer, w io.Writer) (int64, error) {
+	var received int64
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return received, nil
+		}
+		if err != nil {
+			return received, err
+		}
+
+		chunk := req.GetChunk()
+		_, err = w.Write(chunk)
+		if err != nil {
+			return received, status.Error(codes.Internal, err.Error())
+		}
+		received += int64(len(chunk))
+	}
+}
+
+// SubscribeEvents 推送设备事件，直到客户端取消
+// 订阅生效后立即发送响应头，客户端据此确认不会错过之后的事件
+func (s *Server) SubscribeEvents(_ *displaypb.SubscribeEventsRequest, stream displaypb.DisplayService_SubscribeEventsServer) error {
+	events, unsubscribe := s.Client.Events(streamBufferSize)
+	defer unsubscribe()
+
+	err := stream.SendHeader(metadata.MD{})
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case event := <-events:
+			err := stream.Send(eventToProto(event))
+			if err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// SubscribeFrames 推送串口收到的每一帧原始数据，直到客户端取消
+func (s *Server) SubscribeFrames(_ *displaypb.SubscribeFramesRequest, stream displaypb.DisplayService_SubscribeFramesServer) error {
+	frames := make(chan []byte, streamBufferSize)
+	unsubscribe := s.Client.SubscribeFrames(func(frame []byte) {
+		select {
+		case frames <- bytes.Clone(frame):
+		default:
+		}
+	})
+	defer unsubscribe()
+
+	err := stream.SendHeader(metadata.MD{})
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case frame := <-frames:
+			err := stream.Send(&displaypb.Frame{Data: frame})
+			if err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+func eventToProto(event *models.Event) *displaypb.Event {
+	return &displaypb.Event{
+		Type:         displaypb.EventType(event.Type + 1),
+		Code:         uint32(event.Code),
+		Page:         int32(event.Page),
+		Component:    int32(event.Component),
+		Pressed:      event.Pressed,
+		X:            int32(event.X),
+		Y:            int32(event.Y),
+		Raw:          event.Raw,
+		TimeUnixNano: event.Time.UnixNano(),
+	}
+}
+
+// toStatus 将客户端错误转换为 gRPC 状态，设备错误码和升级中断位置作为详情附带，
+// 远程客户端据此还原为 *TjcError 和 *UpgradeError
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	var st *status.Status
+	var details []protoadapt.MessageV1
+
+	var upgradeErr *client.UpgradeError
+	if errors.As(err, &upgradeErr) {
+		st = status.New(codes.Aborted, upgradeErr.Err.Error())
+		details = append(details, &displaypb.UpgradeFailure{Offset: upgradeErr.Offset})
+	}
+
+	var tjcErr *client.TjcError
+	if errors.As(err, &tjcErr) {
+		if st == nil {
+			st = status.New(codes.FailedPrecondition, err.Error())
+		}
+		details = append(details, &displaypb.DeviceError{Code: uint32(tjcErr.Code), Message: tjcErr.Message})
+	}
+
+	if st == nil {
+		return status.Error(codes.Unknown, err.Error())
+	}
+
+	withDetails, detailErr := st.WithDetails(details...)
+	if detailErr != nil {
+		return st.Err()
+	}
+
+	return withDetails.Err()
+}
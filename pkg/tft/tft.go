@@ -0,0 +1,90 @@
+// Package tft 识别 TJC 串口屏程序文件（.tft）的目标型号，用于升级前检查
+//
+// USART HMI 生成的文件头没有公开的格式说明，这里不假设固定的字段偏移，
+// 只在文件开头的 ScanSize 字节中按型号命名规则（如 TJC4024T032_011R、NX4832T035_011R）查找目标设备型号。
+// 分辨率、编辑器版本、校验值等字段的位置无法从实际文件确认，因此不解析。
+//
+// testdata 目录下的实际 .tft 文件（文件名以目标型号开头）用于测试识别结果。
+package tft
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/models"
+)
+
+// ScanSize 查找型号的范围：文件开头的第一个升级数据块
+const ScanSize = 4096
+
+// modelPattern 型号命名规则：系列 + 分辨率 + 类型字母 + 尺寸 + _ + 版本，末尾可带类型后缀
+var modelPattern = regexp.MustCompile(`(TJC|NX)[0-9]{4}[A-Z][0-9]{3}_[0-9]{3}[A-Z]{0,2}`)
+
+var (
+	ErrInvalidHeader = errors.New("invalid tft header")            // 文件开头找不到目标型号，不是 TFT 文件
+	ErrModelMismatch = errors.New("tft model mismatch")            // 程序与设备型号不匹配
+	ErrFlashTooSmall = errors.New("tft file exceeds device flash") // 程序大于设备 Flash
+)
+
+// Header TFT 文件信息
+type Header struct {
+	Model    string // 目标设备型号
+	FileSize int64  // 文件大小
+}
+
+// ParseModel 在文件开头查找目标设备型号
+func ParseModel(r io.Reader) (string, error) {
+	buf := make([]byte, ScanSize)
+	n, err := io.ReadFull(r, buf)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return "", fmt.Errorf("%w: %v", ErrInvalidHeader, err)
+	}
+
+	model := modelPattern.Find(buf[:n])
+	if model == nil {
+		return "", fmt.Errorf("%w: no device model found in the first %d bytes", ErrInvalidHeader, ScanSize)
+	}
+
+	return string(model), nil
+}
+
+// Open 识别程序文件，size 为文件大小
+func Open(r io.ReaderAt, size int64) (*Header, error) {
+	model, err := ParseModel(io.NewSectionReader(r, 0, min(size, ScanSize)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Header{Model: model, FileSize: size}, nil
+}
+
+// CheckCompatible 检查程序文件是否适用于设备：型号一致且不超过 Flash 大小
+func (h *Header) CheckCompatible(info *models.DeviceInfo) error {
+	if !strings.EqualFold(strings.TrimSpace(h.Model), strings.TrimSpace(info.Model)) {
+		return fmt.Errorf("%w: file is for %s, device is %s", ErrModelMismatch, h.Model, info.Model)
+	}
+
+	if info.FlashSize > 0 && h.FileSize > int64(info.FlashSize) {
+		return fmt.Errorf("%w: file is %d bytes, device flash is %d bytes", ErrFlashTooSmall, h.FileSize, info.FlashSize)
+	}
+
+	return nil
+}
+
+// Check 识别程序文件并与设备型号、Flash 大小比对，识别成功后才调用 deviceInfo 读取设备信息
+func Check(r io.ReaderAt, size int64, deviceInfo func() (*models.DeviceInfo, error)) error {
+	h, err := Open(r, size)
+	if err != nil {
+		return err
+	}
+
+	info, err := deviceInfo()
+	if err != nil {
+		return fmt.Errorf("failed to get device info: %w", err)
+	}
+
+	return h.CheckCompatible(info)
+}
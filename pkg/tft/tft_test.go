@@ -0,0 +1,131 @@
+package tft
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/blue-cloud-net/tjc-serial-display/pkg/models"
+)
+
+// TestOpen_Testdata 识别 testdata 下 USART HMI 生成的实际文件，文件名以目标型号开头（如 TJC4024T032_011R-demo.tft）
+func TestOpen_Testdata(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "*.tft"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Skip("no sample tft files in testdata")
+	}
+
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want, _, _ := strings.Cut(strings.TrimSuffix(filepath.Base(path), ".tft"), "-")
+			h, err := Open(bytes.NewReader(data), int64(len(data)))
+			if err != nil {
+				t.Fatalf("Open failed: %v", err)
+			}
+			if h.Model != want {
+				t.Errorf("Expected model %s, got %s", want, h.Model)
+			}
+		})
+	}
+}
+
+func TestParseModel(t *testing.T) {
+	// 型号前后是二进制数据，可能紧挨着其他可打印字符
+	prefix := append(bytes.Repeat([]byte{0x00, 0xFF, 0x5A}, 20), 'x')
+	suffix := []byte{0x00, 0x01, 0xC8}
+
+	testCases := []struct {
+		name  string
+		model string
+	}{
+		{"TJC", "TJC4024T032_011R"},
+		{"TJCCapacitive", "TJC8048X570_011C"},
+		{"Nextion", "NX4832T035_011R"},
+		{"TwoLetterSuffix", "TJC4832K035_011RN"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := append(append(bytes.Clone(prefix), tc.model...), suffix...)
+			model, err := ParseModel(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("ParseModel failed: %v", err)
+			}
+			if model != tc.model {
+				t.Errorf("Expected %s, got %s", tc.model, model)
+			}
+		})
+	}
+}
+
+func TestParseModel_Invalid(t *testing.T) {
+	beyondScan := append(bytes.Repeat([]byte{0xAB}, ScanSize), "TJC4024T032_011R"...)
+
+	testCases := []struct {
+		name string
+		data []byte
+	}{
+		{"Empty", nil},
+		{"NotTFT", bytes.Repeat([]byte{0xAB}, ScanSize)},
+		{"Incomplete", []byte("TJC4024T032")},
+		{"BeyondScan", beyondScan},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := ParseModel(bytes.NewReader(tc.data)); !errors.Is(err, ErrInvalidHeader) {
+				t.Errorf("Expected ErrInvalidHeader, got %v", err)
+			}
+		})
+	}
+}
+
+func TestHeader_CheckCompatible(t *testing.T) {
+	h := &Header{Model: "TJC4024T032_011R", FileSize: 2048}
+
+	if err := h.CheckCompatible(&models.DeviceInfo{Model: "tjc4024t032_011r", FlashSize: 4096}); err != nil {
+		t.Errorf("Expected compatible, got %v", err)
+	}
+	if err := h.CheckCompatible(&models.DeviceInfo{Model: "TJC3224T028_011R", FlashSize: 4096}); !errors.Is(err, ErrModelMismatch) {
+		t.Errorf("Expected ErrModelMismatch, got %v", err)
+	}
+	if err := h.CheckCompatible(&models.DeviceInfo{Model: "TJC4024T032_011R", FlashSize: 1024}); !errors.Is(err, ErrFlashTooSmall) {
+		t.Errorf("Expected ErrFlashTooSmall, got %v", err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	data := append([]byte("\x00\x01TJC4024T032_011R\x00"), bytes.Repeat([]byte{0x5A}, 1000)...)
+	device := &models.DeviceInfo{Model: "TJC4024T032_011R"}
+
+	var queried bool
+	deviceInfo := func() (*models.DeviceInfo, error) {
+		queried = true
+		return device, nil
+	}
+
+	if err := Check(bytes.NewReader(data), int64(len(data)), deviceInfo); err != nil {
+		t.Errorf("Expected check to pass, got %v", err)
+	}
+
+	// 无法识别的文件不读取设备信息
+	queried = false
+	data = bytes.Repeat([]byte{0x5A}, 1000)
+	if err := Check(bytes.NewReader(data), int64(len(data)), deviceInfo); !errors.Is(err, ErrInvalidHeader) {
+		t.Errorf("Expected ErrInvalidHeader, got %v", err)
+	}
+	if queried {
+		t.Error("Expected device info not to be read for an unrecognized file")
+	}
+}